@@ -3,7 +3,6 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,7 +16,7 @@ func datasourceAccount() *schema.Resource {
 }
 
 func datasourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	name := d.Get("name").(string)
 
@@ -3,7 +3,6 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -18,7 +17,7 @@ func datasourceAccountTeam() *schema.Resource {
 }
 
 func datasourceAccountTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	name := d.Get("name").(string)
 	accountId := d.Get("account_id").(string)
@@ -4,7 +4,6 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -19,7 +18,7 @@ func datasourceConnectionPool() *schema.Resource {
 }
 
 func datasourceConnectionPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
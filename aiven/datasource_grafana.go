@@ -1,13 +1,24 @@
 package aiven
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceGrafana() *schema.Resource {
 	return &schema.Resource{
-		ReadContext: datasourceServiceRead,
+		ReadContext: datasourceGrafanaRead,
 		Description: "The Grafana data source provides information about the existing Aiven Grafana service.",
 		Schema:      resourceSchemaAsDatasourceSchema(grafanaSchema(), "project", "service_name"),
 	}
 }
+
+func datasourceGrafanaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if di := datasourceServiceRead(ctx, d, m); di.HasError() {
+		return di
+	}
+
+	return setGrafanaAdminPassword(d)
+}
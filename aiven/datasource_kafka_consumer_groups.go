@@ -0,0 +1,170 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceKafkaConsumerGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceKafkaConsumerGroupsRead,
+		Description: "The Kafka Consumer Groups data source lists the consumer groups of an existing Aiven Kafka service with their committed offsets and lag per partition.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service name",
+			},
+			"topics": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Topics to list the consumer groups of, all the topics of the service by default",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"consumer_groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Consumer groups that have committed offsets on the topics. Group membership is not reported by the Aiven API.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Consumer group name",
+						},
+						"lag": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Total lag of the consumer group over all its partitions",
+						},
+						"partitions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Partitions the consumer group has committed offsets on",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"topic_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Topic name",
+									},
+									"partition": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Partition number",
+									},
+									"offset": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Committed offset of the consumer group",
+									},
+									"lag": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Number of messages between the committed offset and the latest offset of the partition",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceKafkaConsumerGroupsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	topics, err := getKafkaTopics(client, projectName, serviceName, flattenToString(d.Get("topics").([]interface{})))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	if err := d.Set("consumer_groups", flattenKafkaConsumerGroups(topics)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenKafkaConsumerGroups groups the committed offsets of the topic partitions by
+// consumer group, groups are sorted by name and partitions by topic and number
+func flattenKafkaConsumerGroups(topics []*aiven.KafkaTopic) []map[string]interface{} {
+	type partitionOffset struct {
+		topic     string
+		partition int
+		offset    int64
+		lag       int64
+	}
+
+	byGroup := make(map[string][]partitionOffset)
+	for _, t := range topics {
+		for _, p := range t.Partitions {
+			for _, g := range p.ConsumerGroups {
+				lag := p.LatestOffset - g.Offset
+				if lag < 0 {
+					lag = 0
+				}
+				byGroup[g.GroupName] = append(byGroup[g.GroupName], partitionOffset{
+					topic:     t.TopicName,
+					partition: p.Partition,
+					offset:    g.Offset,
+					lag:       lag,
+				})
+			}
+		}
+	}
+
+	var names []string
+	for name := range byGroup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var groups []map[string]interface{}
+	for _, name := range names {
+		offsets := byGroup[name]
+		sort.Slice(offsets, func(i, j int) bool {
+			if offsets[i].topic != offsets[j].topic {
+				return offsets[i].topic < offsets[j].topic
+			}
+			return offsets[i].partition < offsets[j].partition
+		})
+
+		var total int64
+		var partitions []map[string]interface{}
+		for _, o := range offsets {
+			total += o.lag
+			partitions = append(partitions, map[string]interface{}{
+				"topic_name": o.topic,
+				"partition":  o.partition,
+				"offset":     int(o.offset),
+				"lag":        int(o.lag),
+			})
+		}
+
+		groups = append(groups, map[string]interface{}{
+			"group_name": name,
+			"lag":        int(total),
+			"partitions": partitions,
+		})
+	}
+
+	return groups
+}
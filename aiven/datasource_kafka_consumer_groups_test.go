@@ -0,0 +1,105 @@
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_flattenKafkaConsumerGroups(t *testing.T) {
+	topics := []*aiven.KafkaTopic{
+		{
+			TopicName: "orders",
+			Partitions: []*aiven.Partition{
+				{
+					Partition:      1,
+					LatestOffset:   120,
+					ConsumerGroups: []*aiven.ConsumerGroup{{GroupName: "billing", Offset: 100}},
+				},
+				{
+					Partition:      0,
+					LatestOffset:   50,
+					ConsumerGroups: []*aiven.ConsumerGroup{{GroupName: "billing", Offset: 50}},
+				},
+			},
+		},
+		{
+			TopicName: "payments",
+			Partitions: []*aiven.Partition{
+				{Partition: 0, LatestOffset: 10},
+			},
+		},
+	}
+
+	want := []map[string]interface{}{
+		{
+			"group_name": "billing",
+			"lag":        20,
+			"partitions": []map[string]interface{}{
+				{"topic_name": "orders", "partition": 0, "offset": 50, "lag": 0},
+				{"topic_name": "orders", "partition": 1, "offset": 100, "lag": 20},
+			},
+		},
+	}
+
+	if got := flattenKafkaConsumerGroups(topics); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenKafkaConsumerGroups() = %v, want %v", got, want)
+	}
+}
+
+func TestAccAivenKafkaConsumerGroupsDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_kafka_consumer_groups.groups"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaConsumerGroupsDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(datasourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					// nothing consumes the new topic yet
+					resource.TestCheckResourceAttr(datasourceName, "consumer_groups.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaConsumerGroupsDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+		}
+
+		resource "aiven_kafka_topic" "foo" {
+			project = data.aiven_project.foo.project
+			service_name = aiven_kafka.bar.service_name
+			topic_name = "test-acc-topic-%s"
+			partitions = 3
+			replication = 2
+		}
+
+		data "aiven_kafka_consumer_groups" "groups" {
+			project = aiven_kafka_topic.foo.project
+			service_name = aiven_kafka_topic.foo.service_name
+			topics = [aiven_kafka_topic.foo.topic_name]
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
@@ -3,7 +3,6 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -22,7 +21,7 @@ func datasourceKafkaSchemaRead(ctx context.Context, d *schema.ResourceData, m in
 	serviceName := d.Get("service_name").(string)
 	subjectName := d.Get("subject_name").(string)
 
-	subjects, err := m.(*aiven.Client).KafkaSubjectSchemas.List(projectName, serviceName)
+	subjects, err := m.(*providerMeta).client.KafkaSubjectSchemas.List(projectName, serviceName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -2,6 +2,7 @@ package aiven
 
 import (
 	"context"
+	"sort"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -9,24 +10,106 @@ import (
 )
 
 func datasourceKafkaSchemaConfiguration() *schema.Resource {
+	s := resourceSchemaAsDatasourceSchema(aivenKafkaSchemaConfigurationSchema,
+		"project", "service_name")
+	s["subjects"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Compatibility levels of the subjects of the schema registry",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"subject_name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Kafka Schema Subject name",
+				},
+				"compatibility_level": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Compatibility level of the subject, the global one when the subject has none of its own",
+				},
+				"global": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Tells if the subject uses the global compatibility level",
+				},
+			},
+		},
+	}
+
 	return &schema.Resource{
 		ReadContext: datasourceKafkaSchemasConfigurationRead,
-		Description: "The Kafka Schema Configuration data source provides information about the existing Aiven Kafka Schema Configuration.",
-		Schema: resourceSchemaAsDatasourceSchema(aivenKafkaSchemaSchema,
-			"project", "service_name"),
+		Description: "The Kafka Schema Configuration data source provides the global and per subject compatibility levels of the schema registry of an existing Aiven Kafka service.",
+		Schema:      s,
 	}
 }
 
 func datasourceKafkaSchemasConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 
-	_, err := m.(*aiven.Client).KafkaGlobalSchemaConfig.Get(projectName, serviceName)
+	global, err := client.KafkaGlobalSchemaConfig.Get(projectName, serviceName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	subjects, err := client.KafkaSubjectSchemas.List(projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot list schema subjects of %s/%s: %s", projectName, serviceName, err)
+	}
+
+	levels := make(map[string]string)
+	for _, subject := range subjects.Subjects {
+		c, err := client.KafkaSubjectSchemas.GetConfiguration(projectName, serviceName, subject)
+		if err != nil {
+			if aiven.IsNotFound(err) {
+				continue
+			}
+			return diag.Errorf("cannot get the configuration of schema subject %s of %s/%s: %s",
+				subject, projectName, serviceName, err)
+		}
+		levels[subject] = c.CompatibilityLevel
+	}
+
 	d.SetId(buildResourceID(projectName, serviceName))
 
-	return resourceKafkaSchemaConfigurationRead(ctx, d, m)
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("compatibility_level", global.CompatibilityLevel); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("subjects", flattenKafkaSchemaSubjectsCompatibility(subjects.Subjects, levels, global.CompatibilityLevel)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenKafkaSchemaSubjectsCompatibility reports the compatibility level of every subject,
+// the subjects without a level of their own get the global one, subjects are sorted by name
+func flattenKafkaSchemaSubjectsCompatibility(subjects []string, levels map[string]string, global string) []map[string]interface{} {
+	names := append([]string(nil), subjects...)
+	sort.Strings(names)
+
+	var result []map[string]interface{}
+	for _, name := range names {
+		level, ok := levels[name]
+		if !ok || level == "" {
+			level = global
+		}
+
+		result = append(result, map[string]interface{}{
+			"subject_name":        name,
+			"compatibility_level": level,
+			"global":              !ok || levels[name] == "",
+		})
+	}
+
+	return result
 }
@@ -0,0 +1,76 @@
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_flattenKafkaSchemaSubjectsCompatibility(t *testing.T) {
+	subjects := []string{"payments-value", "orders-value", "orders-key"}
+	levels := map[string]string{
+		"orders-value": "FULL",
+	}
+
+	want := []map[string]interface{}{
+		{"subject_name": "orders-key", "compatibility_level": "BACKWARD", "global": true},
+		{"subject_name": "orders-value", "compatibility_level": "FULL", "global": false},
+		{"subject_name": "payments-value", "compatibility_level": "BACKWARD", "global": true},
+	}
+
+	if got := flattenKafkaSchemaSubjectsCompatibility(subjects, levels, "BACKWARD"); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenKafkaSchemaSubjectsCompatibility() = %v, want %v", got, want)
+	}
+}
+
+func TestAccAivenKafkaSchemaConfigurationDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_kafka_schema_configuration.config"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaSchemaConfigurationDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(datasourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					// the default global compatibility of the schema registry
+					resource.TestCheckResourceAttr(datasourceName, "compatibility_level", "BACKWARD"),
+					resource.TestCheckResourceAttr(datasourceName, "subjects.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaSchemaConfigurationDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+
+			kafka_user_config {
+				schema_registry = true
+			}
+		}
+
+		data "aiven_kafka_schema_configuration" "config" {
+			project = aiven_kafka.bar.project
+			service_name = aiven_kafka.bar.service_name
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
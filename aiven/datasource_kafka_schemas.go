@@ -0,0 +1,113 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceKafkaSchemas() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceKafkaSchemasRead,
+		Description: "The Kafka Schemas data source lists the subjects registered in the schema registry of an existing Aiven Kafka service with their versions, e.g. to audit them.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service name",
+			},
+			"subjects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Subjects of the schema registry, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subject_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Kafka Schema Subject name",
+						},
+						"latest_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Latest version of the subject",
+						},
+						"versions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Versions of the subject in ascending order",
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceKafkaSchemasRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	subjects, err := client.KafkaSubjectSchemas.List(projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot list schema subjects of %s/%s: %s", projectName, serviceName, err)
+	}
+
+	versions := make(map[string][]int)
+	for _, subject := range subjects.Subjects {
+		r, err := client.KafkaSubjectSchemas.GetVersions(projectName, serviceName, subject)
+		if err != nil {
+			return diag.Errorf("cannot get the versions of schema subject %s of %s/%s: %s",
+				subject, projectName, serviceName, err)
+		}
+		versions[subject] = r.Versions
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	if err := d.Set("subjects", flattenKafkaSchemaSubjects(versions)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenKafkaSchemaSubjects lists the subjects by name with their versions in ascending
+// order and the latest one
+func flattenKafkaSchemaSubjects(versions map[string][]int) []map[string]interface{} {
+	var names []string
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var subjects []map[string]interface{}
+	for _, name := range names {
+		v := append([]int(nil), versions[name]...)
+		sort.Ints(v)
+
+		var latest int
+		if len(v) > 0 {
+			latest = v[len(v)-1]
+		}
+
+		subjects = append(subjects, map[string]interface{}{
+			"subject_name":   name,
+			"latest_version": latest,
+			"versions":       v,
+		})
+	}
+
+	return subjects
+}
@@ -0,0 +1,124 @@
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_flattenKafkaSchemaSubjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions map[string][]int
+		want     []map[string]interface{}
+	}{
+		{
+			"empty",
+			map[string][]int{},
+			nil,
+		},
+		{
+			"two subjects",
+			map[string][]int{
+				"payments-value": {1},
+				"orders-value":   {3, 1, 2},
+			},
+			[]map[string]interface{}{
+				{"subject_name": "orders-value", "latest_version": 3, "versions": []int{1, 2, 3}},
+				{"subject_name": "payments-value", "latest_version": 1, "versions": []int{1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flattenKafkaSchemaSubjects(tt.versions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flattenKafkaSchemaSubjects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccAivenKafkaSchemasDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_kafka_schemas.schemas"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaSchemasDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(datasourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(datasourceName, "subjects.#", "2"),
+					resource.TestCheckResourceAttr(datasourceName, "subjects.0.subject_name", fmt.Sprintf("kafka-schema-a-%s", rName)),
+					resource.TestCheckResourceAttr(datasourceName, "subjects.0.latest_version", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "subjects.0.versions.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "subjects.1.subject_name", fmt.Sprintf("kafka-schema-b-%s", rName)),
+					resource.TestCheckResourceAttr(datasourceName, "subjects.1.latest_version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaSchemasDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+
+			kafka_user_config {
+				schema_registry = true
+			}
+		}
+
+		resource "aiven_kafka_schema" "a" {
+			project = data.aiven_project.foo.project
+			service_name = aiven_kafka.bar.service_name
+			subject_name = "kafka-schema-a-%s"
+
+			schema = <<EOT
+				{
+					"name": "a",
+					"type": "record",
+					"fields": [{"name": "id", "type": "int"}]
+				}
+			EOT
+		}
+
+		resource "aiven_kafka_schema" "b" {
+			project = data.aiven_project.foo.project
+			service_name = aiven_kafka.bar.service_name
+			subject_name = "kafka-schema-b-%s"
+
+			schema = <<EOT
+				{
+					"name": "b",
+					"type": "record",
+					"fields": [{"name": "name", "type": "string"}]
+				}
+			EOT
+		}
+
+		data "aiven_kafka_schemas" "schemas" {
+			project = aiven_kafka.bar.project
+			service_name = aiven_kafka.bar.service_name
+
+			depends_on = [aiven_kafka_schema.a, aiven_kafka_schema.b]
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name)
+}
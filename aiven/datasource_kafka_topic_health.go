@@ -0,0 +1,145 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceKafkaTopicHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceKafkaTopicHealthRead,
+		Description: "The Kafka Topic Health data source reports the under-replicated and offline partitions of the topics of an existing Aiven Kafka service, from the in-sync replicas of their partitions.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service name",
+			},
+			"topics": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Topics to report the health of, all the topics of the service by default",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"under_replicated_partition_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of under-replicated partitions over all the topics",
+			},
+			"offline_partition_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of offline partitions over all the topics",
+			},
+			"topic_health": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Health of each topic",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"topic_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Topic name",
+						},
+						"replication": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Replication factor of the topic",
+						},
+						"under_replicated_partitions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Partitions that have fewer in-sync replicas than the replication factor of the topic",
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+						"offline_partitions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Partitions that have no in-sync replica",
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceKafkaTopicHealthRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	topics, err := getKafkaTopics(client, projectName, serviceName, flattenToString(d.Get("topics").([]interface{})))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	health, underReplicated, offline := flattenKafkaTopicHealth(topics)
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	if err := d.Set("topic_health", health); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("under_replicated_partition_count", underReplicated); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("offline_partition_count", offline); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenKafkaTopicHealth reports the under-replicated and offline partitions of the topics
+// sorted by name, and their total counts. A partition without in-sync replicas is offline,
+// one with fewer in-sync replicas than the replication factor of its topic is under-replicated.
+func flattenKafkaTopicHealth(topics []*aiven.KafkaTopic) ([]map[string]interface{}, int, int) {
+	sorted := make([]*aiven.KafkaTopic, len(topics))
+	copy(sorted, topics)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].TopicName < sorted[j].TopicName
+	})
+
+	var health []map[string]interface{}
+	var underReplicatedCount, offlineCount int
+	for _, t := range sorted {
+		underReplicated := []int{}
+		offline := []int{}
+		for _, p := range t.Partitions {
+			switch {
+			case p.ISR == 0:
+				offline = append(offline, p.Partition)
+			case p.ISR < t.Replication:
+				underReplicated = append(underReplicated, p.Partition)
+			}
+		}
+		sort.Ints(underReplicated)
+		sort.Ints(offline)
+
+		underReplicatedCount += len(underReplicated)
+		offlineCount += len(offline)
+
+		health = append(health, map[string]interface{}{
+			"topic_name":                  t.TopicName,
+			"replication":                 t.Replication,
+			"under_replicated_partitions": underReplicated,
+			"offline_partitions":          offline,
+		})
+	}
+
+	return health, underReplicatedCount, offlineCount
+}
@@ -0,0 +1,109 @@
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_flattenKafkaTopicHealth(t *testing.T) {
+	topics := []*aiven.KafkaTopic{
+		{
+			TopicName:   "payments",
+			Replication: 3,
+			Partitions: []*aiven.Partition{
+				{Partition: 0, ISR: 3},
+				{Partition: 1, ISR: 0},
+			},
+		},
+		{
+			TopicName:   "orders",
+			Replication: 3,
+			Partitions: []*aiven.Partition{
+				{Partition: 1, ISR: 3},
+				{Partition: 0, ISR: 2},
+			},
+		},
+	}
+
+	wantHealth := []map[string]interface{}{
+		{
+			"topic_name":                  "orders",
+			"replication":                 3,
+			"under_replicated_partitions": []int{0},
+			"offline_partitions":          []int{},
+		},
+		{
+			"topic_name":                  "payments",
+			"replication":                 3,
+			"under_replicated_partitions": []int{},
+			"offline_partitions":          []int{1},
+		},
+	}
+
+	health, underReplicated, offline := flattenKafkaTopicHealth(topics)
+	if !reflect.DeepEqual(health, wantHealth) {
+		t.Errorf("flattenKafkaTopicHealth() health = %v, want %v", health, wantHealth)
+	}
+	if underReplicated != 1 || offline != 1 {
+		t.Errorf("flattenKafkaTopicHealth() under-replicated = %d, offline = %d, want 1, 1", underReplicated, offline)
+	}
+}
+
+func TestAccAivenKafkaTopicHealthDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_kafka_topic_health.health"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaTopicHealthDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "topic_health.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "topic_health.0.topic_name", fmt.Sprintf("test-acc-topic-%s", rName)),
+					resource.TestCheckResourceAttr(datasourceName, "topic_health.0.replication", "2"),
+					resource.TestCheckResourceAttr(datasourceName, "under_replicated_partition_count", "0"),
+					resource.TestCheckResourceAttr(datasourceName, "offline_partition_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaTopicHealthDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+		}
+
+		resource "aiven_kafka_topic" "foo" {
+			project = data.aiven_project.foo.project
+			service_name = aiven_kafka.bar.service_name
+			topic_name = "test-acc-topic-%s"
+			partitions = 3
+			replication = 2
+		}
+
+		data "aiven_kafka_topic_health" "health" {
+			project = aiven_kafka_topic.foo.project
+			service_name = aiven_kafka_topic.foo.service_name
+			topics = [aiven_kafka_topic.foo.topic_name]
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceOpensearchACLEvaluate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceOpensearchACLEvaluateRead,
+		Description: "The Opensearch ACL Evaluate data source reports the permission a user gets on an index of an existing Aiven Opensearch service from its current ACL config and rules. The evaluation is done by the provider and mirrors the documented Aiven ACL semantics, the service remains the authority on access.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service name",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The username to evaluate the access of",
+			},
+			"index": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The index, or top-level API such as `_msearch`, to evaluate the access to",
+			},
+			"permission": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: complex("The effective permission of the user on the index").possibleValues("deny", "admin", "read", "readwrite", "write").build(),
+			},
+			"matching_rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The ACL rules that apply to the user and the index",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The username pattern of the ACL entry",
+						},
+						"index": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The index pattern of the rule",
+						},
+						"permission": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The permission of the rule",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceOpensearchACLEvaluateRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	username := d.Get("username").(string)
+	index := d.Get("index").(string)
+
+	r, err := client.ElasticsearchACLs.Get(projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot get ACL config of %s/%s: %s", projectName, serviceName, err)
+	}
+
+	permission, rules := evaluateOpensearchACL(r.ElasticSearchACLConfig, username, index)
+
+	d.SetId(buildResourceID(projectName, serviceName, username, index))
+
+	if err := d.Set("permission", permission); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("matching_rules", rules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// evaluateOpensearchACL returns the effective permission of the user on the index and the
+// rules it is derived from. When ACLs are disabled every user has full access, top-level
+// APIs (names starting with `_`) are only subject to the rules in extended ACL mode.
+// Usernames and indexes are matched against the patterns of the entries and rules with
+// `*` and `?` wildcards, a matching deny rule overrides every other rule, read and write
+// rules add up and no matching rule means no access.
+func evaluateOpensearchACL(cfg aiven.ElasticSearchACLConfig, username, index string) (string, []map[string]interface{}) {
+	if !cfg.Enabled || (strings.HasPrefix(index, "_") && !cfg.ExtendedAcl) {
+		return "admin", nil
+	}
+
+	var rules []map[string]interface{}
+	var read, write, admin, deny bool
+	for _, acl := range cfg.ACLs {
+		if !opensearchACLPatternMatch(acl.Username, username) {
+			continue
+		}
+		for _, rule := range acl.Rules {
+			if !opensearchACLPatternMatch(rule.Index, index) {
+				continue
+			}
+
+			rules = append(rules, map[string]interface{}{
+				"username":   acl.Username,
+				"index":      rule.Index,
+				"permission": rule.Permission,
+			})
+
+			switch rule.Permission {
+			case "deny":
+				deny = true
+			case "admin":
+				admin = true
+			case "readwrite":
+				read, write = true, true
+			case "read":
+				read = true
+			case "write":
+				write = true
+			}
+		}
+	}
+
+	switch {
+	case deny:
+		return "deny", rules
+	case admin:
+		return "admin", rules
+	case read && write:
+		return "readwrite", rules
+	case read:
+		return "read", rules
+	case write:
+		return "write", rules
+	default:
+		return "deny", rules
+	}
+}
+
+// opensearchACLPatternMatch tells if the value matches the ACL pattern, `*` matches any
+// number of characters and `?` a single one
+func opensearchACLPatternMatch(pattern, value string) bool {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.MustCompile(expr.String()).MatchString(value)
+}
@@ -0,0 +1,137 @@
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_evaluateOpensearchACL(t *testing.T) {
+	acls := []aiven.ElasticSearchACL{
+		{
+			Username: "app-*",
+			Rules: []aiven.ElasticsearchACLRule{
+				{Index: "logs-*", Permission: "read"},
+				{Index: "logs-2021.??.??", Permission: "write"},
+				{Index: "secrets", Permission: "deny"},
+				{Index: "_msearch", Permission: "read"},
+			},
+		},
+		{
+			Username: "app-billing",
+			Rules: []aiven.ElasticsearchACLRule{
+				{Index: "secrets*", Permission: "admin"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		extendedACL bool
+		username    string
+		index       string
+		want        string
+		wantRules   int
+	}{
+		{"acl disabled", false, false, "someone", "secrets", "admin", 0},
+		{"wildcard user and index", true, false, "app-web", "logs-2020", "read", 1},
+		{"read and write add up", true, false, "app-web", "logs-2021.01.31", "readwrite", 2},
+		{"single character wildcard", true, false, "app-web", "logs-2021.1.31", "read", 1},
+		{"deny overrides admin", true, false, "app-billing", "secrets", "deny", 2},
+		{"admin", true, false, "app-billing", "secrets-2021", "admin", 1},
+		{"no matching user", true, false, "web", "logs-2020", "deny", 0},
+		{"no matching index", true, false, "app-web", "metrics", "deny", 0},
+		{"top-level API without extended ACL", true, false, "web", "_msearch", "admin", 0},
+		{"top-level API with extended ACL", true, true, "app-web", "_msearch", "read", 1},
+		{"unmatched top-level API with extended ACL", true, true, "app-web", "_bulk", "deny", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := aiven.ElasticSearchACLConfig{
+				ACLs:        acls,
+				Enabled:     tt.enabled,
+				ExtendedAcl: tt.extendedACL,
+			}
+
+			got, rules := evaluateOpensearchACL(cfg, tt.username, tt.index)
+			if got != tt.want {
+				t.Errorf("evaluateOpensearchACL() = %s, want %s", got, tt.want)
+			}
+			if len(rules) != tt.wantRules {
+				t.Errorf("evaluateOpensearchACL() matching rules = %v, want %d", rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestAccAivenOpensearchACLEvaluateDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_opensearch_acl_evaluate.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchACLEvaluateDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(datasourceName, "service_name", fmt.Sprintf("test-acc-sr-acleval-%s", rName)),
+					resource.TestCheckResourceAttr(datasourceName, "permission", "readwrite"),
+					resource.TestCheckResourceAttr(datasourceName, "matching_rules.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "matching_rules.0.index", "test-index*"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOpensearchACLEvaluateDataSource(name string) string {
+	return fmt.Sprintf(`
+    data "aiven_project" "foo" {
+      project = "%s"
+    }
+
+    resource "aiven_opensearch" "bar" {
+      project = data.aiven_project.foo.project
+      cloud_name = "google-europe-west1"
+      plan = "startup-4"
+      service_name = "test-acc-sr-acleval-%s"
+      maintenance_window_dow = "monday"
+      maintenance_window_time = "10:00:00"
+    }
+
+    resource "aiven_service_user" "foo" {
+      service_name = aiven_opensearch.bar.service_name
+      project = data.aiven_project.foo.project
+      username = "user-%s"
+    }
+
+    resource "aiven_opensearch_acl_config" "foo" {
+      project = data.aiven_project.foo.project
+      service_name = aiven_opensearch.bar.service_name
+      enabled = true
+      extended_acl = true
+    }
+
+    resource "aiven_opensearch_acl_rule" "foo" {
+      project = aiven_opensearch_acl_config.foo.project
+      service_name = aiven_opensearch_acl_config.foo.service_name
+      username = aiven_service_user.foo.username
+      index = "test-index*"
+      permission = "readwrite"
+    }
+
+    data "aiven_opensearch_acl_evaluate" "foo" {
+      project = aiven_opensearch_acl_rule.foo.project
+      service_name = aiven_opensearch_acl_rule.foo.service_name
+      username = aiven_opensearch_acl_rule.foo.username
+      index = "test-index-2021"
+    }
+    `, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
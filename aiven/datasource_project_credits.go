@@ -0,0 +1,62 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceProjectCredits() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceProjectCreditsRead,
+		Description: "The Project Credits data source provides the remaining credits and the estimated balance of an existing Aiven Project.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"available_credits": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The amount of platform credits available to the project. This could be your free trial or other promotional credits.",
+			},
+			"estimated_balance": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current accumulated bill for this project in the current billing period.",
+			},
+			"billing_currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The currency of the credits and the balance",
+			},
+		},
+	}
+}
+
+func datasourceProjectCreditsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	project, err := client.Projects.Get(projectName)
+	if err != nil {
+		return diag.Errorf("cannot get project %s: %s", projectName, err)
+	}
+
+	d.SetId(projectName)
+
+	if err := d.Set("available_credits", project.AvailableCredits); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("estimated_balance", project.EstimatedBalance); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("billing_currency", project.BillingCurrency); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
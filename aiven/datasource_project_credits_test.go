@@ -0,0 +1,37 @@
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenProjectCreditsDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_project_credits.credits"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectCreditsDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttrSet(datasourceName, "available_credits"),
+					resource.TestCheckResourceAttrSet(datasourceName, "estimated_balance"),
+					resource.TestCheckResourceAttrSet(datasourceName, "billing_currency"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProjectCreditsDataSource() string {
+	return fmt.Sprintf(`
+		data "aiven_project_credits" "credits" {
+			project = "%s"
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"))
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceRedisBackup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceRedisBackupRead,
+		Description: "The Redis Backup data source provides information about the latest backup of an existing Aiven Redis service, e.g. to reference it in a migration. Backups are taken by Aiven on its own schedule.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service name",
+			},
+			"backup_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the latest backup of the service",
+			},
+			"data_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the latest backup of the service in bytes",
+			},
+			"backup_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of backups kept for the service",
+			},
+		},
+	}
+}
+
+func datasourceRedisBackupRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if service.Type != ServiceTypeRedis {
+		return diag.Errorf("service %s/%s is a %s service, not a %s one", projectName, serviceName, service.Type, ServiceTypeRedis)
+	}
+
+	backup := latestServiceBackup(service.Backups)
+	if backup == nil {
+		return diag.Errorf("service %s/%s has no backup yet", projectName, serviceName)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	if err := d.Set("backup_time", backup.BackupTime); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("data_size", backup.DataSize); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("backup_count", len(service.Backups)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// latestServiceBackup returns the most recent backup, nil when there is none, a backup with
+// a time that does not parse is only returned when no other backup has one
+func latestServiceBackup(backups []*aiven.Backup) *aiven.Backup {
+	var latest *aiven.Backup
+	var latestTime time.Time
+	for _, b := range backups {
+		t, _ := time.Parse(time.RFC3339, b.BackupTime)
+		if latest == nil || t.After(latestTime) {
+			latest, latestTime = b, t
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,102 @@
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_latestServiceBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		backups []*aiven.Backup
+		want    string
+	}{
+		{
+			"no backup",
+			nil,
+			"",
+		},
+		{
+			"latest in the middle",
+			[]*aiven.Backup{
+				{BackupTime: "2021-10-19T08:00:00Z", DataSize: 100},
+				{BackupTime: "2021-10-21T08:00:00.123456Z", DataSize: 300},
+				{BackupTime: "2021-10-20T08:00:00Z", DataSize: 200},
+			},
+			"2021-10-21T08:00:00.123456Z",
+		},
+		{
+			"unparsable time",
+			[]*aiven.Backup{
+				{BackupTime: "yesterday"},
+				{BackupTime: "2021-10-19T08:00:00Z"},
+			},
+			"2021-10-19T08:00:00Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := latestServiceBackup(tt.backups)
+			if got == nil {
+				if tt.want != "" {
+					t.Errorf("latestServiceBackup() = nil, want %s", tt.want)
+				}
+				return
+			}
+			if got.BackupTime != tt.want {
+				t.Errorf("latestServiceBackup() = %s, want %s", got.BackupTime, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccAivenRedisBackupDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_redis_backup.backup"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the service is created once its first backup is taken
+				Config: testAccRedisBackupDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(datasourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttrSet(datasourceName, "backup_time"),
+					resource.TestCheckResourceAttrSet(datasourceName, "data_size"),
+					resource.TestCheckResourceAttrSet(datasourceName, "backup_count"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRedisBackupDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_redis" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+		}
+
+		data "aiven_redis_backup" "backup" {
+			project = aiven_redis.bar.project
+			service_name = aiven_redis.bar.service_name
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
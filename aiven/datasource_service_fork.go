@@ -0,0 +1,108 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceServiceFork() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceServiceForkRead,
+		Description: "The Service Fork data source reports the service an existing Aiven service was forked from with `service_to_fork_from`, and whether the fork is ready to take over from it. It is meant for renaming a service by forking it, see the Renaming Services guide.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the fork",
+			},
+			"source_service_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the service the fork must be forked from, the read fails when the fork is forked from another service",
+			},
+			"forked_from": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the service the fork was forked from",
+			},
+			"forked_from_project": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Project of the service the fork was forked from",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the fork, e.g. `REBUILDING` or `RUNNING`",
+			},
+			"ready": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the fork is `RUNNING`, clients can be moved to it once it is",
+			},
+		},
+	}
+}
+
+func datasourceServiceForkRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot get service %s/%s: %s", projectName, serviceName, err)
+	}
+
+	forkedFrom, forkedFromProject := serviceForkedFrom(service, projectName)
+	if forkedFrom == "" {
+		return diag.Errorf("service %s/%s is not a fork, it has no service_to_fork_from in its user config",
+			projectName, serviceName)
+	}
+	if source := d.Get("source_service_name").(string); source != "" && source != forkedFrom {
+		return diag.Errorf("service %s/%s is forked from %s, not from %s", projectName, serviceName, forkedFrom, source)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	if err := d.Set("forked_from", forkedFrom); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("forked_from_project", forkedFromProject); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", service.State); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ready", service.State == "RUNNING"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// serviceForkedFrom reads the service a service was forked from and its project from the
+// user config of the service, a fork of a service of the same project has no project_to_fork_from
+func serviceForkedFrom(service *aiven.Service, project string) (string, string) {
+	forkedFrom, _ := service.UserConfig["service_to_fork_from"].(string)
+	if forkedFrom == "" {
+		return "", ""
+	}
+
+	forkedFromProject, _ := service.UserConfig["project_to_fork_from"].(string)
+	if forkedFromProject == "" {
+		forkedFromProject = project
+	}
+
+	return forkedFrom, forkedFromProject
+}
@@ -0,0 +1,43 @@
+package aiven
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+func Test_serviceForkedFrom(t *testing.T) {
+	tests := []struct {
+		name        string
+		userConfig  map[string]interface{}
+		wantService string
+		wantProject string
+	}{
+		{
+			"not a fork",
+			map[string]interface{}{"pg_version": "13"},
+			"",
+			"",
+		},
+		{
+			"fork in the same project",
+			map[string]interface{}{"service_to_fork_from": "my-pg"},
+			"my-pg",
+			"test-project",
+		},
+		{
+			"fork from another project",
+			map[string]interface{}{"service_to_fork_from": "my-pg", "project_to_fork_from": "other-project"},
+			"my-pg",
+			"other-project",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, project := serviceForkedFrom(&aiven.Service{UserConfig: tt.userConfig}, "test-project")
+			if service != tt.wantService || project != tt.wantProject {
+				t.Errorf("serviceForkedFrom() = %v, %v, want %v, %v", service, project, tt.wantService, tt.wantProject)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceServiceIntegrations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceServiceIntegrationsRead,
+		Description: "The Service Integrations data source lists the integrations an existing Aiven service is the source or the destination of, e.g. to render the dependency graph of the services of a project.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service name",
+			},
+			"integrations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Integrations of the service",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"integration_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Service Integration Id at aiven",
+						},
+						"integration_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the service integration",
+						},
+						"role": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: complex("Role of the service in the integration").possibleValues("source", "destination").build(),
+						},
+						"source_service_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Source service of the integration, if any",
+						},
+						"destination_service_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Destination service of the integration, if any",
+						},
+						"peer_service_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The other service of the integration, if it is not an endpoint",
+						},
+						"peer_endpoint_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The other endpoint of the integration as `project_name/endpoint_id`, if it is not a service",
+						},
+						"active": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Tells if the integration is active",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Tells if the integration is enabled",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceServiceIntegrationsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	integrations, err := client.ServiceIntegrations.List(projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot list integrations of %s/%s: %s", projectName, serviceName, err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	if err := d.Set("integrations", flattenServiceIntegrationsGraph(projectName, serviceName, integrations)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenServiceIntegrationsGraph describes the integrations from the point of view of the
+// service, with its role and the other end of every integration, integrations are sorted by
+// type and ID
+func flattenServiceIntegrationsGraph(project, serviceName string, integrations []*aiven.ServiceIntegration) []map[string]interface{} {
+	sorted := append([]*aiven.ServiceIntegration(nil), integrations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].IntegrationType != sorted[j].IntegrationType {
+			return sorted[i].IntegrationType < sorted[j].IntegrationType
+		}
+		return sorted[i].ServiceIntegrationID < sorted[j].ServiceIntegrationID
+	})
+
+	var result []map[string]interface{}
+	for _, i := range sorted {
+		var source, destination string
+		if i.SourceService != nil {
+			source = *i.SourceService
+		}
+		if i.DestinationService != nil {
+			destination = *i.DestinationService
+		}
+
+		role, peerService, peerEndpoint := "source", destination, i.DestinationEndpointID
+		if destination == serviceName {
+			role, peerService, peerEndpoint = "destination", source, i.SourceEndpointID
+		}
+
+		peerEndpointID := ""
+		if peerEndpoint != nil {
+			peerEndpointID = buildResourceID(project, *peerEndpoint)
+		}
+
+		result = append(result, map[string]interface{}{
+			"integration_id":           buildResourceID(project, i.ServiceIntegrationID),
+			"integration_type":         i.IntegrationType,
+			"role":                     role,
+			"source_service_name":      source,
+			"destination_service_name": destination,
+			"peer_service_name":        peerService,
+			"peer_endpoint_id":         peerEndpointID,
+			"active":                   i.Active,
+			"enabled":                  i.Enabled,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,166 @@
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_flattenServiceIntegrationsGraph(t *testing.T) {
+	pg, influxdb, grafana := "test-pg", "test-influxdb", "test-grafana"
+	endpoint := "endpoint-id"
+
+	integrations := []*aiven.ServiceIntegration{
+		{
+			ServiceIntegrationID: "metrics-id",
+			IntegrationType:      "metrics",
+			SourceService:        &pg,
+			DestinationService:   &influxdb,
+			Active:               true,
+			Enabled:              true,
+		},
+		{
+			ServiceIntegrationID:  "logs-id",
+			IntegrationType:       "logs",
+			SourceService:         &influxdb,
+			DestinationEndpointID: &endpoint,
+			Active:                true,
+			Enabled:               true,
+		},
+		{
+			ServiceIntegrationID: "dashboard-id",
+			IntegrationType:      "dashboard",
+			SourceService:        &grafana,
+			DestinationService:   &influxdb,
+			Active:               false,
+			Enabled:              true,
+		},
+	}
+
+	want := []map[string]interface{}{
+		{
+			"integration_id":           "test-project/dashboard-id",
+			"integration_type":         "dashboard",
+			"role":                     "destination",
+			"source_service_name":      grafana,
+			"destination_service_name": influxdb,
+			"peer_service_name":        grafana,
+			"peer_endpoint_id":         "",
+			"active":                   false,
+			"enabled":                  true,
+		},
+		{
+			"integration_id":           "test-project/logs-id",
+			"integration_type":         "logs",
+			"role":                     "source",
+			"source_service_name":      influxdb,
+			"destination_service_name": "",
+			"peer_service_name":        "",
+			"peer_endpoint_id":         "test-project/endpoint-id",
+			"active":                   true,
+			"enabled":                  true,
+		},
+		{
+			"integration_id":           "test-project/metrics-id",
+			"integration_type":         "metrics",
+			"role":                     "destination",
+			"source_service_name":      pg,
+			"destination_service_name": influxdb,
+			"peer_service_name":        pg,
+			"peer_endpoint_id":         "",
+			"active":                   true,
+			"enabled":                  true,
+		},
+	}
+
+	if got := flattenServiceIntegrationsGraph("test-project", influxdb, integrations); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenServiceIntegrationsGraph() = %v, want %v", got, want)
+	}
+}
+
+func TestAccAivenServiceIntegrationsDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_service_integrations.graph"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceIntegrationsDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(datasourceName, "service_name", fmt.Sprintf("test-acc-sr-influxdb-%s", rName)),
+					resource.TestCheckResourceAttr(datasourceName, "integrations.#", "2"),
+					resource.TestCheckResourceAttr(datasourceName, "integrations.0.integration_type", "dashboard"),
+					resource.TestCheckResourceAttr(datasourceName, "integrations.0.role", "destination"),
+					resource.TestCheckResourceAttr(datasourceName, "integrations.0.peer_service_name", fmt.Sprintf("test-acc-sr-grafana-%s", rName)),
+					resource.TestCheckResourceAttr(datasourceName, "integrations.1.integration_type", "metrics"),
+					resource.TestCheckResourceAttr(datasourceName, "integrations.1.role", "destination"),
+					resource.TestCheckResourceAttr(datasourceName, "integrations.1.peer_service_name", fmt.Sprintf("test-acc-sr-pg-%s", rName)),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceIntegrationsDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar-pg" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-pg-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+		}
+
+		resource "aiven_influxdb" "bar-influxdb" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-influxdb-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+		}
+
+		resource "aiven_grafana" "bar-grafana" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-1"
+			service_name = "test-acc-sr-grafana-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+		}
+
+		resource "aiven_service_integration" "metrics" {
+			project = data.aiven_project.foo.project
+			integration_type = "metrics"
+			source_service_name = aiven_pg.bar-pg.service_name
+			destination_service_name = aiven_influxdb.bar-influxdb.service_name
+		}
+
+		resource "aiven_service_integration" "dashboard" {
+			project = data.aiven_project.foo.project
+			integration_type = "dashboard"
+			source_service_name = aiven_grafana.bar-grafana.service_name
+			destination_service_name = aiven_influxdb.bar-influxdb.service_name
+		}
+
+		data "aiven_service_integrations" "graph" {
+			project = data.aiven_project.foo.project
+			service_name = aiven_influxdb.bar-influxdb.service_name
+
+			depends_on = [aiven_service_integration.metrics, aiven_service_integration.dashboard]
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceServiceUserConfig() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"project": {
+			Type:        schema.TypeString,
+			Description: "Project name",
+		},
+		"service_name": {
+			Type:        schema.TypeString,
+			Description: "Service name",
+		},
+		"service_type": {
+			Type:        schema.TypeString,
+			Description: "Aiven internal service type code",
+		},
+	}
+	for t := range templates.GetUserConfigSchema("service") {
+		s[t+"_user_config"] = generateServiceUserConfiguration(t)
+	}
+
+	return &schema.Resource{
+		ReadContext: datasourceServiceUserConfigRead,
+		Description: "The Service User Config data source provides the effective user configuration of an existing Aiven service, " +
+			"including the default values of the settings that are not explicitly set.",
+		Schema: resourceSchemaAsDatasourceSchema(s, "project", "service_name"),
+	}
+}
+
+func datasourceServiceUserConfigRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("service %s/%s not found: %s", projectName, serviceName, err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	if err := d.Set("service_type", service.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat("service", service.Type, service.UserConfig)
+	if err := d.Set(service.Type+"_user_config", userConfig); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set `%s_user_config`: %w", service.Type, err))
+	}
+
+	return nil
+}
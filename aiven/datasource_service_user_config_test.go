@@ -0,0 +1,66 @@
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenServiceUserConfigDataSource_pg(t *testing.T) {
+	datasourceName := "data.aiven_service_user_config.config"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceUserConfigDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(datasourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(datasourceName, "service_type", "pg"),
+					resource.TestCheckResourceAttr(datasourceName, "pg_user_config.#", "1"),
+					// explicitly set value
+					resource.TestCheckResourceAttr(datasourceName, "pg_user_config.0.public_access.0.pg", "true"),
+					// server default
+					resource.TestCheckResourceAttrSet(datasourceName, "pg_user_config.0.pg_version"),
+					resource.TestCheckResourceAttr(datasourceName, "kafka_user_config.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceUserConfigDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+
+			pg_user_config {
+				public_access {
+					pg = true
+				}
+			}
+		}
+
+		data "aiven_service_user_config" "config" {
+			project = aiven_pg.bar.project
+			service_name = aiven_pg.bar.service_name
+
+			depends_on = [ aiven_pg.bar ]
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
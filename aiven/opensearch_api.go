@@ -0,0 +1,31 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+func opensearchServiceAPI(client *aiven.Client, project, serviceName string) (*serviceAPI, error) {
+	service, err := getServiceOfType(client, project, serviceName, ServiceTypeOpensearch)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProjectServiceAPI(client, project, service, service.URI)
+}
+
+func opensearchDashboardsAPI(client *aiven.Client, project, serviceName string) (*serviceAPI, error) {
+	service, err := getServiceOfType(client, project, serviceName, ServiceTypeOpensearch)
+	if err != nil {
+		return nil, err
+	}
+
+	if service.ConnectionInfo.OpensearchDashboardsURI == "" {
+		return nil, fmt.Errorf("service %s/%s has no OpenSearch Dashboards URI, "+
+			"make sure `opensearch_dashboards` is enabled in `opensearch_user_config`", project, serviceName)
+	}
+
+	return newProjectServiceAPI(client, project, service, service.ConnectionInfo.OpensearchDashboardsURI)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/aiven/terraform-provider-aiven/pkg/cache"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -29,6 +31,23 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("AIVEN_TOKEN", nil),
 				Description: "Aiven Authentication Token",
 			},
+			"state_change_webhook": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AIVEN_STATE_CHANGE_WEBHOOK", nil),
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsURLWithHTTPorHTTPS),
+				Description: "URL the provider POSTs to on every service state transition observed while waiting " +
+					"for a service, failures to deliver are logged and ignored",
+			},
+			"request_user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AIVEN_USER_AGENT_SUFFIX", nil),
+				ValidateFunc: validation.Any(validation.StringIsEmpty,
+					validation.StringMatch(regexp.MustCompile(`^[\x21-\x7e]+( [\x21-\x7e]+)*$`), "must be printable ASCII")),
+				Description: "Appended to the User-Agent of the API requests, e.g. a team identifier, so the changes " +
+					"made by the provider can be attributed in the Aiven audit logs",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -37,13 +56,17 @@ func Provider() *schema.Provider {
 			"aiven_kafka_acl":                      datasourceKafkaACL(),
 			"aiven_kafka_topic":                    datasourceKafkaTopic(),
 			"aiven_kafka_connector":                datasourceKafkaConnector(),
+			"aiven_kafka_consumer_groups":          datasourceKafkaConsumerGroups(),
+			"aiven_kafka_topic_health":             datasourceKafkaTopicHealth(),
 			"aiven_kafka_schema":                   datasourceKafkaSchema(),
 			"aiven_kafka_schema_configuration":     datasourceKafkaSchemaConfiguration(),
+			"aiven_kafka_schemas":                  datasourceKafkaSchemas(),
 			"aiven_project":                        datasourceProject(),
 			"aiven_project_user":                   datasourceProjectUser(),
 			"aiven_project_vpc":                    datasourceProjectVPC(),
 			"aiven_vpc_peering_connection":         datasourceVPCPeeringConnection(),
 			"aiven_service_integration":            datasourceServiceIntegration(),
+			"aiven_service_integrations":           datasourceServiceIntegrations(),
 			"aiven_service_integration_endpoint":   datasourceServiceIntegrationEndpoint(),
 			"aiven_service_user":                   datasourceServiceUser(),
 			"aiven_account":                        datasourceAccount(),
@@ -64,6 +87,7 @@ func Provider() *schema.Provider {
 			"aiven_grafana":                        datasourceGrafana(),
 			"aiven_influxdb":                       datasourceInfluxDB(),
 			"aiven_redis":                          datasourceRedis(),
+			"aiven_redis_backup":                   datasourceRedisBackup(),
 			"aiven_transit_gateway_vpc_attachment": datasourceTransitGatewayVPCAttachment(),
 			"aiven_service_component":              datasourceServiceComponent(),
 			"aiven_m3db":                           datasourceM3DB(),
@@ -72,8 +96,12 @@ func Provider() *schema.Provider {
 			"aiven_opensearch":                     datasourceOpensearch(),
 			"aiven_opensearch_acl_config":          datasourceOpensearchACLConfig(),
 			"aiven_opensearch_acl_rule":            datasourceOpensearchACLRule(),
+			"aiven_opensearch_acl_evaluate":        datasourceOpensearchACLEvaluate(),
 			"aiven_flink":                          datasourceFlink(),
 			"aiven_azure_privatelink":              datasourceAzurePrivatelink(),
+			"aiven_service_user_config":            datasourceServiceUserConfig(),
+			"aiven_service_fork":                   datasourceServiceFork(),
+			"aiven_project_credits":                datasourceProjectCredits(),
 
 			// deprecated
 			"aiven_elasticsearch_acl": datasourceElasticsearchACL(),
@@ -112,6 +140,7 @@ func Provider() *schema.Provider {
 			"aiven_elasticsearch_acl_rule":         resourceElasticsearchACLRule(),
 			"aiven_grafana":                        resourceGrafana(),
 			"aiven_influxdb":                       resourceInfluxDB(),
+			"aiven_influxdb_retention_policy":      resourceInfluxDBRetentionPolicy(),
 			"aiven_redis":                          resourceRedis(),
 			"aiven_transit_gateway_vpc_attachment": resourceTransitGatewayVPCAttachment(),
 			"aiven_m3db":                           resourceM3DB(),
@@ -121,6 +150,11 @@ func Provider() *schema.Provider {
 			"aiven_opensearch":                     resourceOpensearch(),
 			"aiven_opensearch_acl_config":          resourceOpensearchACLConfig(),
 			"aiven_opensearch_acl_rule":            resourceOpensearchACLRule(),
+			"aiven_opensearch_rollup":              resourceOpensearchRollup(),
+			"aiven_opensearch_saved_objects":       resourceOpensearchSavedObjects(),
+			"aiven_opensearch_snapshot":            resourceOpensearchSnapshot(),
+			"aiven_opensearch_reindex":             resourceOpensearchReindex(),
+			"aiven_grafana_datasource":             resourceGrafanaDatasource(),
 			"aiven_azure_privatelink":              resourceAzurePrivatelink(),
 
 			// flink
@@ -145,17 +179,28 @@ func Provider() *schema.Provider {
 
 		client, err := aiven.NewTokenClient(
 			d.Get("api_token").(string),
-			fmt.Sprintf("terraform-provider-aiven/%s", terraformVersion))
+			providerUserAgent(terraformVersion, d.Get("request_user_agent_suffix").(string)))
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
-		return client, nil
+		return &providerMeta{
+			client:             client,
+			stateChangeWebhook: d.Get("state_change_webhook").(string),
+		}, nil
 	}
 
 	return p
 }
 
+// providerMeta is passed to the resources and data sources, it carries the client and the
+// provider options of one provider configuration
+type providerMeta struct {
+	client *aiven.Client
+	// stateChangeWebhook is configured by the provider `state_change_webhook` option
+	stateChangeWebhook string
+}
+
 func optionalString(d *schema.ResourceData, key string) string {
 	str, ok := d.Get(key).(string)
 	if !ok {
@@ -414,6 +459,17 @@ func resourceReadHandleNotFound(err error, d *schema.ResourceData) error {
 	return err
 }
 
+// providerUserAgent builds the User-Agent of the API requests, the suffix is set by the
+// provider configuration
+func providerUserAgent(terraformVersion, suffix string) string {
+	userAgent := fmt.Sprintf("terraform-provider-aiven/%s", terraformVersion)
+	if suffix != "" {
+		userAgent += " " + suffix
+	}
+
+	return userAgent
+}
+
 // generateServiceUserConfiguration generate service user_config
 func generateServiceUserConfiguration(t string) *schema.Schema {
 	s := GenerateTerraformUserConfigSchema(
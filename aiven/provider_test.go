@@ -1,12 +1,19 @@
 package aiven
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var (
@@ -37,6 +44,60 @@ func TestProviderImpl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+// Test_providerConfigureOptionalDefaults configures the provider with the api_token only, the
+// optional options must then be off
+func Test_providerConfigureOptionalDefaults(t *testing.T) {
+	for _, env := range []string{"AIVEN_STATE_CHANGE_WEBHOOK", "AIVEN_USER_AGENT_SUFFIX"} {
+		if os.Getenv(env) != "" {
+			t.Skipf("%s is set", env)
+		}
+	}
+
+	p := Provider()
+	c := terraform.NewResourceConfigRaw(map[string]interface{}{"api_token": "token"})
+
+	for _, d := range p.Validate(c) {
+		if d.Severity != diag.Error {
+			continue
+		}
+		for _, k := range []string{"state_change_webhook", "request_user_agent_suffix"} {
+			if strings.Contains(d.Summary, k) {
+				t.Errorf("Validate() error = %s", d.Summary)
+			}
+		}
+	}
+
+	if diags := p.Configure(context.Background(), c); diags.HasError() {
+		t.Fatalf("Configure() errors = %v", diags)
+	}
+	if webhook := p.Meta().(*providerMeta).stateChangeWebhook; webhook != "" {
+		t.Errorf("state_change_webhook = %q, want it off", webhook)
+	}
+}
+
+// Test_providerConfigureStateChangeWebhook configures two providers with different webhooks,
+// e.g. two aliases, each of them must keep its own
+func Test_providerConfigureStateChangeWebhook(t *testing.T) {
+	var providers []*schema.Provider
+	for _, webhook := range []string{"https://hooks.example.com/a", "https://hooks.example.com/b"} {
+		p := Provider()
+		c := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"api_token":            "token",
+			"state_change_webhook": webhook,
+		})
+		if diags := p.Configure(context.Background(), c); diags.HasError() {
+			t.Fatalf("Configure() errors = %v", diags)
+		}
+		providers = append(providers, p)
+	}
+
+	for i, want := range []string{"https://hooks.example.com/a", "https://hooks.example.com/b"} {
+		if got := providers[i].Meta().(*providerMeta).stateChangeWebhook; got != want {
+			t.Errorf("state_change_webhook of provider %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("AIVEN_TOKEN"); v == "" {
 		t.Log(v)
@@ -92,3 +153,87 @@ func Test_validateDurationString(t *testing.T) {
 		})
 	}
 }
+
+func Test_providerUserAgent(t *testing.T) {
+	tests := []struct {
+		name   string
+		suffix string
+		want   string
+	}{
+		{
+			"no suffix",
+			"",
+			"terraform-provider-aiven/1.0.0",
+		},
+		{
+			"team suffix",
+			"team/data-platform",
+			"terraform-provider-aiven/1.0.0 team/data-platform",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := providerUserAgent("1.0.0", tt.suffix); got != tt.want {
+				t.Errorf("providerUserAgent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testRedirectTransport sends the requests of the API client to a test server
+type testRedirectTransport struct {
+	target *url.URL
+}
+
+func (t testRedirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func Test_providerConfigureUserAgent(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"project":{"project_name":"test-project"}}`))
+	}))
+	defer srv.Close()
+
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := Provider()
+	p.TerraformVersion = "1.0.0"
+	c := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_token":                 "token",
+		"request_user_agent_suffix": "team/data-platform",
+	})
+	if diags := p.Configure(context.Background(), c); diags.HasError() {
+		t.Fatalf("Configure() errors = %v", diags)
+	}
+
+	client := p.Meta().(*providerMeta).client
+	client.Client.Transport = testRedirectTransport{target: target}
+	if _, err := client.Projects.Get("test-project"); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "terraform-provider-aiven/1.0.0 team/data-platform"; got != want {
+		t.Errorf("User-Agent = %q, want %q", got, want)
+	}
+}
+
+func Test_requestUserAgentSuffixValidation(t *testing.T) {
+	validate := Provider().Schema["request_user_agent_suffix"].ValidateFunc
+
+	if _, errs := validate("team/data-platform", "request_user_agent_suffix"); len(errs) > 0 {
+		t.Errorf("validate() errors = %v, want none", errs)
+	}
+	if _, errs := validate("team\ndata-platform", "request_user_agent_suffix"); len(errs) == 0 {
+		t.Error("validate() accepted a suffix with a newline")
+	}
+}
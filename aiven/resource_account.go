@@ -58,7 +58,7 @@ func resourceAccount() *schema.Resource {
 }
 
 func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	name := d.Get("name").(string)
 
 	r, err := client.Accounts.Create(
@@ -76,7 +76,7 @@ func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	r, err := client.Accounts.Get(d.Id())
 	if err != nil {
@@ -106,7 +106,7 @@ func resourceAccountRead(_ context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	r, err := client.Accounts.Update(d.Id(), aiven.Account{
 		Name: d.Get("name").(string),
@@ -121,7 +121,7 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.Accounts.Delete(d.Id())
 	if err != nil && !aiven.IsNotFound(err) {
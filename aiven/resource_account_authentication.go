@@ -91,7 +91,7 @@ func resourceAccountAuthentication() *schema.Resource {
 }
 
 func resourceAccountAuthenticationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId := d.Get("account_id").(string)
 
@@ -118,7 +118,7 @@ func resourceAccountAuthenticationCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceAccountAuthenticationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, authId := splitResourceID2(d.Id())
 	r, err := client.AccountAuthentications.Get(accountId, authId)
@@ -167,7 +167,7 @@ func resourceAccountAuthenticationRead(_ context.Context, d *schema.ResourceData
 }
 
 func resourceAccountAuthenticationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	accountId, authId := splitResourceID2(d.Id())
 
 	r, err := client.AccountAuthentications.Update(accountId, aiven.AccountAuthenticationMethod{
@@ -191,7 +191,7 @@ func resourceAccountAuthenticationUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceAccountAuthenticationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId := splitResourceID2(d.Id())
 
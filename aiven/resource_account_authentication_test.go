@@ -56,7 +56,7 @@ func testAccAccountAuthenticationResource(name string) string {
 }
 
 func testAccCheckAivenAccountAuthenticationResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each account authentication is destroyed
 	for _, rs := range s.RootModule().Resources {
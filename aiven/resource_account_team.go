@@ -53,7 +53,7 @@ func resourceAccountTeam() *schema.Resource {
 }
 
 func resourceAccountTeamCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	name := d.Get("name").(string)
 	accountId := d.Get("account_id").(string)
 
@@ -73,7 +73,7 @@ func resourceAccountTeamCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceAccountTeamRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId := splitResourceID2(d.Id())
 	r, err := client.AccountTeams.Get(accountId, teamId)
@@ -101,7 +101,7 @@ func resourceAccountTeamRead(_ context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountTeamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	accountId, teamId := splitResourceID2(d.Id())
 
 	r, err := client.AccountTeams.Update(accountId, teamId, aiven.AccountTeam{
@@ -117,7 +117,7 @@ func resourceAccountTeamUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceAccountTeamDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId := splitResourceID2(d.Id())
 
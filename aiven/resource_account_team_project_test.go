@@ -116,7 +116,7 @@ func testAccAccountTeamProjectResource(name string) string {
 }
 
 func testAccCheckAivenAccountTeamProjectResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each account team project is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -96,7 +96,7 @@ func testAccAccountTeamResource(name string) string {
 }
 
 func testAccCheckAivenAccountTeamResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each account team is destroyed
 	for _, rs := range s.RootModule().Resources {
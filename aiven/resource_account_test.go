@@ -84,7 +84,7 @@ func testAccAccountResource(name string) string {
 }
 
 func testAccCheckAivenAccountResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each account is destroyed
 	for _, rs := range s.RootModule().Resources {
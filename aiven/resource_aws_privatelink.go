@@ -55,7 +55,7 @@ func resourceAWSPrivatelink() *schema.Resource {
 }
 
 func resourceAWSPrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	var principals []string
 	var project = d.Get("project").(string)
@@ -76,7 +76,7 @@ func resourceAWSPrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m
 
 	// Wait until the AWS privatelink is active
 	w := &AWSPrivatelinkWaiter{
-		Client:      m.(*aiven.Client),
+		Client:      m.(*providerMeta).client,
 		Project:     project,
 		ServiceName: serviceName,
 	}
@@ -92,7 +92,7 @@ func resourceAWSPrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceAWSPrivatelinkRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName := splitResourceID2(d.Id())
 	p, err := client.AWSPrivatelink.Get(project, serviceName)
@@ -119,7 +119,7 @@ func resourceAWSPrivatelinkRead(_ context.Context, d *schema.ResourceData, m int
 	return nil
 }
 func resourceAWSPrivatelinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName := splitResourceID2(d.Id())
 
@@ -139,7 +139,7 @@ func resourceAWSPrivatelinkUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	// Wait until the AWS privatelink is active
 	w := &AWSPrivatelinkWaiter{
-		Client:      m.(*aiven.Client),
+		Client:      m.(*providerMeta).client,
 		Project:     project,
 		ServiceName: serviceName,
 	}
@@ -153,7 +153,7 @@ func resourceAWSPrivatelinkUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceAWSPrivatelinkDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.AWSPrivatelink.Delete(splitResourceID2(d.Id()))
 	if err != nil && !aiven.IsNotFound(err) {
@@ -38,7 +38,7 @@ func TestAccAivenAWSPrivatelink_basic(t *testing.T) {
 }
 
 func testAccCheckAivenAWSPrivatelinkResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each AWS privatelink is destroyed
 	for _, rs := range s.RootModule().Resources {
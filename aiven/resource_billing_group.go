@@ -107,7 +107,7 @@ func resourceBillingGroup() *schema.Resource {
 }
 
 func resourceBillingGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	var billingEmails []*aiven.ContactEmail
 	if emails := contactEmailListForAPI(d, "billing_emails", true); emails != nil {
@@ -146,7 +146,7 @@ func resourceBillingGroupCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceBillingGroupRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	bg, err := client.BillingGroup.Get(d.Id())
 	if err != nil {
@@ -197,7 +197,7 @@ func resourceBillingGroupRead(_ context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceBillingGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	var billingEmails []*aiven.ContactEmail
 	if emails := contactEmailListForAPI(d, "billing_emails", true); emails != nil {
@@ -237,7 +237,7 @@ func resourceBillingGroupUpdate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceBillingGroupDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.BillingGroup.Delete(d.Id())
 	if err != nil && !aiven.IsNotFound(err) {
@@ -45,7 +45,7 @@ func TestAccAivenBillingGroup_basic(t *testing.T) {
 }
 
 func testAccCheckAivenBillingGroupResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each billing group is destroyed
 	for _, rs := range s.RootModule().Resources {
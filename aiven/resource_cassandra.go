@@ -31,9 +31,11 @@ func resourceCassandra() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeCassandra),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: cassandraSchema(),
@@ -70,7 +70,7 @@ func resourceConnectionPool() *schema.Resource {
 }
 
 func resourceConnectionPoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -96,7 +96,7 @@ func resourceConnectionPoolCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceConnectionPoolRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, poolName := splitResourceID3(d.Id())
 	pool, err := client.ConnectionPools.Get(project, serviceName, poolName)
@@ -113,7 +113,7 @@ func resourceConnectionPoolRead(_ context.Context, d *schema.ResourceData, m int
 }
 
 func resourceConnectionPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, poolName := splitResourceID3(d.Id())
 	_, err := client.ConnectionPools.Update(
@@ -135,7 +135,7 @@ func resourceConnectionPoolUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceConnectionPoolDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, poolName := splitResourceID3(d.Id())
 	err := client.ConnectionPools.Delete(projectName, serviceName, poolName)
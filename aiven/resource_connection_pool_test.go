@@ -82,6 +82,11 @@ func TestAccAivenConnectionPool_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "pool_name", fmt.Sprintf("test-acc-pool-%s", rName)),
 					resource.TestCheckResourceAttr(resourceName, "pool_size", "25"),
 					resource.TestCheckResourceAttr(resourceName, "pool_mode", "transaction"),
+					resource.TestCheckResourceAttr("data.aiven_pg.service", "connection_pools.#", "1"),
+					resource.TestCheckResourceAttr("data.aiven_pg.service", "connection_pools.0.pool_name", fmt.Sprintf("test-acc-pool-%s", rName)),
+					resource.TestCheckResourceAttr("data.aiven_pg.service", "connection_pools.0.database_name", fmt.Sprintf("test-acc-db-%s", rName)),
+					resource.TestCheckResourceAttr("data.aiven_pg.service", "connection_pools.0.pool_size", "25"),
+					resource.TestCheckResourceAttrSet("data.aiven_pg.service", "connection_pools.0.connection_uri"),
 				),
 			},
 			{
@@ -187,6 +192,13 @@ func testAccConnectionPoolResource(name string) string {
 
 			depends_on = [aiven_connection_pool.foo]
 		}
+
+		data "aiven_pg" "service" {
+			project = aiven_connection_pool.foo.project
+			service_name = aiven_connection_pool.foo.service_name
+
+			depends_on = [aiven_connection_pool.foo]
+		}
 		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, name)
 }
 
@@ -228,7 +240,7 @@ func testAccCheckAivenConnectionPoolAttributes(n string) resource.TestCheckFunc
 }
 
 func testAccCheckAivenConnectionPoolResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each connection pool is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -38,9 +38,11 @@ func resourceElasticsearch() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeElasticsearch),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema:             elasticsearchSchema(),
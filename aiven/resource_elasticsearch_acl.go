@@ -85,7 +85,7 @@ The Elasticsearch ACL resource allows the creation and management of ACLs for an
 }
 
 func resourceElasticsearchACLRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName := splitResourceID2(d.Id())
 	r, err := client.ElasticsearchACLs.Get(project, serviceName)
@@ -148,7 +148,7 @@ func resourceElasticsearchACLState(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceElasticsearchACLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -187,7 +187,7 @@ func resourceElasticsearchACLUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceElasticsearchACLDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -25,6 +25,25 @@ func aivenFlinkSchema() map[string]*schema.Schema {
 						Type: schema.TypeString,
 					},
 				},
+				"jobmanager": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Host and port of the Flink job managers, parsed from host_ports",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"host": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Job manager host",
+							},
+							"port": {
+								Type:        schema.TypeInt,
+								Computed:    true,
+								Description: "Job manager port",
+							},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -43,9 +62,11 @@ func resourceFlink() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeFlink),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: aivenFlinkSchema(),
@@ -62,7 +62,7 @@ func resourceFlinkJob() *schema.Resource {
 }
 
 func resourceFlinkJobRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, jobId := splitResourceID3(d.Id())
 
@@ -99,7 +99,7 @@ func resourceFlinkJobRead(_ context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceFlinkJobCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -157,7 +157,7 @@ func resourceFlinkJobCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceFlinkJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, jobId := splitResourceID3(d.Id())
 
@@ -2,6 +2,7 @@ package aiven
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -72,7 +73,7 @@ func resourceFlinkTable() *schema.Resource {
 }
 
 func resourceFlinkTableRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, tableId := splitResourceID3(d.Id())
 
@@ -101,7 +102,7 @@ func resourceFlinkTableRead(_ context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceFlinkTableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -123,6 +124,10 @@ func resourceFlinkTableCreate(ctx context.Context, d *schema.ResourceData, m int
 		SchemaSQL:     schemaSQL,
 	}
 
+	if err := validateFlinkTableIntegration(client, project, serviceName, integrationId); err != nil {
+		return diag.FromErr(err)
+	}
+
 	r, err := client.FlinkTables.Create(project, serviceName, createRequest)
 	if err != nil {
 		return diag.FromErr(err)
@@ -133,8 +138,35 @@ func resourceFlinkTableCreate(ctx context.Context, d *schema.ResourceData, m int
 	return resourceFlinkTableRead(ctx, d, m)
 }
 
+// validateFlinkTableIntegration checks that the integration of a table is an active flink integration
+// of the service, creating a table before its integration is ready fails with an unclear error
+func validateFlinkTableIntegration(client *aiven.Client, project, serviceName, integrationId string) error {
+	integration, err := client.ServiceIntegrations.Get(project, integrationId)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return fmt.Errorf("service integration %s does not exist, it must be created before the Flink table", integrationId)
+		}
+		return fmt.Errorf("cannot get service integration %s: %s", integrationId, err)
+	}
+
+	if integration.IntegrationType != "flink" {
+		return fmt.Errorf("service integration %s is of type %s, a Flink table requires a flink integration",
+			integrationId, integration.IntegrationType)
+	}
+
+	if integration.DestinationService == nil || *integration.DestinationService != serviceName {
+		return fmt.Errorf("service integration %s does not integrate with Flink service %s", integrationId, serviceName)
+	}
+
+	if !integration.Active {
+		return fmt.Errorf("service integration %s is not active yet", integrationId)
+	}
+
+	return nil
+}
+
 func resourceFlinkTableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, tableId := splitResourceID3(d.Id())
 
@@ -3,6 +3,8 @@ package aiven
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/aiven/aiven-go-client"
@@ -11,6 +13,21 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func Test_flattenFlinkHostPorts(t *testing.T) {
+	got := flattenFlinkHostPorts([]string{
+		"test-flink.aivencloud.com:26500",
+		"[2001:db8::1]:26501",
+		"invalid",
+	})
+	want := []map[string]interface{}{
+		{"host": "test-flink.aivencloud.com", "port": 26500},
+		{"host": "2001:db8::1", "port": 26501},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenFlinkHostPorts() = %v, want %v", got, want)
+	}
+}
+
 func TestAccAiven_flinkBasic(t *testing.T) {
 	resourceName := "aiven_flink.bar"
 	serviceName := fmt.Sprintf("test-acc-flink-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -70,6 +87,8 @@ data "aiven_flink" "service" {
 					resource.TestCheckResourceAttr(resourceName, "flink_user_config.0.number_of_task_slots", "10"),
 					resource.TestCheckResourceAttr(resourceName, "flink_user_config.0.parallelism_default", "2"),
 					resource.TestCheckResourceAttr(resourceName, "flink_user_config.0.restart_strategy", "failure-rate"),
+					resource.TestCheckResourceAttrSet(resourceName, "flink.0.jobmanager.0.host"),
+					resource.TestCheckResourceAttrSet(resourceName, "flink.0.jobmanager.0.port"),
 				),
 			},
 		},
@@ -444,7 +463,7 @@ resource "aiven_flink_job" "testing" {
 }
 
 func testAccCheckAivenFlinkJobsAndTableResourcesDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each job and table is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -500,3 +519,41 @@ func testAccCheckAivenFlinkJobsAndTableResourcesDestroy(s *terraform.State) erro
 
 	return nil
 }
+
+func TestAccAiven_flinkTableMissingIntegration(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-flink-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+
+	manifest := fmt.Sprintf(`
+resource "aiven_flink" "testing" {
+  project = "%s"
+  cloud_name = "google-europe-west1"
+  plan = "business-4"
+  service_name = "%s"
+}
+
+resource "aiven_flink_table" "missing" {
+  project = aiven_flink.testing.project
+  service_name = aiven_flink.testing.service_name
+  integration_id = "00000000-0000-0000-0000-000000000000"
+  table_name = "missing"
+  kafka_topic = "missing"
+  schema_sql = "`+"`cpu`"+` INT"
+}
+`,
+		projectName,
+		serviceName,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenFlinkJobsAndTableResourcesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      manifest,
+				ExpectError: regexp.MustCompile("service integration 00000000-0000-0000-0000-000000000000 does not exist"),
+			},
+		},
+	})
+}
@@ -1,8 +1,11 @@
 package aiven
 
 import (
+	"context"
 	"time"
 
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,6 +20,17 @@ func grafanaSchema() map[string]*schema.Schema {
 		},
 	}
 	s[ServiceTypeGrafana+"_user_config"] = generateServiceUserConfiguration(ServiceTypeGrafana)
+	s["rotate_admin_password"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Changing this value to any other value resets the password of the Grafana admin user.",
+	}
+	s["grafana_admin_password"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "Password of the Grafana admin user.",
+	}
 
 	return s
 }
@@ -24,18 +38,70 @@ func grafanaSchema() map[string]*schema.Schema {
 func resourceGrafana() *schema.Resource {
 	return &schema.Resource{
 		Description:   "The Grafana resource allows the creation and management of Aiven Grafana services.",
-		CreateContext: resourceServiceCreateWrapper(ServiceTypeGrafana),
-		ReadContext:   resourceServiceRead,
-		UpdateContext: resourceServiceUpdate,
+		CreateContext: resourceGrafanaCreate,
+		ReadContext:   resourceGrafanaRead,
+		UpdateContext: resourceGrafanaUpdate,
 		DeleteContext: resourceServiceDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeGrafana),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: grafanaSchema(),
 	}
 }
+
+func resourceGrafanaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if di := resourceServiceCreateWrapper(ServiceTypeGrafana)(ctx, d, m); di.HasError() {
+		return di
+	}
+
+	return setGrafanaAdminPassword(d)
+}
+
+func resourceGrafanaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if di := resourceServiceRead(ctx, d, m); di.HasError() {
+		return di
+	}
+
+	return setGrafanaAdminPassword(d)
+}
+
+func resourceGrafanaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if di := resourceServiceUpdate(ctx, d, m); di.HasError() {
+		return di
+	}
+
+	if d.HasChange("rotate_admin_password") {
+		client := m.(*providerMeta).client
+		projectName, serviceName := splitResourceID2(d.Id())
+
+		// updating a service user without a new password resets its credentials
+		user, err := client.ServiceUsers.Update(projectName, serviceName, d.Get("service_username").(string),
+			aiven.ModifyServiceUserRequest{})
+		if err != nil {
+			return diag.Errorf("cannot reset Grafana admin password: %s", err)
+		}
+
+		if err := d.Set("service_password", user.Password); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return setGrafanaAdminPassword(d)
+}
+
+// setGrafanaAdminPassword exposes the password of the service admin user, which is the
+// Grafana admin user
+func setGrafanaAdminPassword(d *schema.ResourceData) diag.Diagnostics {
+	if err := d.Set("grafana_admin_password", d.Get("service_password")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,343 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenGrafanaDatasourceSchema = map[string]*schema.Schema{
+	"project":      commonSchemaProjectReference,
+	"service_name": commonSchemaServiceNameReference,
+	"name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringLenBetween(1, 190),
+		Description:  "Name of the data source in Grafana",
+	},
+	"type": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Type of the data source, e.g. `prometheus`, `influxdb`, `postgres` or `elasticsearch`",
+	},
+	"url": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "URL of the data source, e.g. the URI of another Aiven service",
+	},
+	"database": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Database of the data source, for the data source types that have one",
+	},
+	"basic_auth_username": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Username Grafana authenticates to the data source with",
+	},
+	"basic_auth_password": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "Password Grafana authenticates to the data source with, it is not read back from Grafana",
+	},
+	"is_default": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: complex("Use the data source by default in new panels.").defaultValue(false).build(),
+	},
+	"json_data": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+		Description:      "Type specific settings of the data source as a JSON object, e.g. `jsonencode({ httpMethod = \"POST\" })`",
+	},
+	"datasource_id": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Numeric ID of the data source in Grafana",
+	},
+	"uid": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "UID of the data source in Grafana, used to reference it from dashboards",
+	},
+}
+
+func resourceGrafanaDatasource() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Grafana Datasource resource provisions a data source on an Aiven Grafana service, e.g. to query the metrics stored in another Aiven service. The data source is managed through the Grafana API of the service with its admin credentials, so the service must be reachable from where Terraform runs.",
+		CreateContext: resourceGrafanaDatasourceCreate,
+		ReadContext:   resourceGrafanaDatasourceRead,
+		UpdateContext: resourceGrafanaDatasourceUpdate,
+		DeleteContext: resourceGrafanaDatasourceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenGrafanaDatasourceSchema,
+	}
+}
+
+func grafanaServiceAPI(client *aiven.Client, project, serviceName string) (*serviceAPI, error) {
+	service, err := getServiceOfType(client, project, serviceName, ServiceTypeGrafana)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProjectServiceAPI(client, project, service, service.URI)
+}
+
+func resourceGrafanaDatasourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	api, err := grafanaServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ds, err := expandGrafanaDatasource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	created, err := api.createGrafanaDatasource(ctx, ds)
+	if err != nil {
+		return diag.Errorf("cannot create Grafana data source %s on %s/%s: %s", ds.Name, project, serviceName, err)
+	}
+
+	d.SetId(buildResourceID(project, serviceName, created.UID))
+
+	return resourceGrafanaDatasourceRead(ctx, d, m)
+}
+
+func resourceGrafanaDatasourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, uid := splitResourceID3(d.Id())
+
+	api, err := grafanaServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	ds, err := api.getGrafanaDatasource(ctx, uid)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := flattenGrafanaDatasource(d, ds); err != nil {
+		return diag.Errorf("cannot set Grafana data source %s: %s", uid, err)
+	}
+
+	return nil
+}
+
+func resourceGrafanaDatasourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, uid := splitResourceID3(d.Id())
+
+	api, err := grafanaServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ds, err := expandGrafanaDatasource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ds.ID = d.Get("datasource_id").(int)
+	ds.UID = uid
+
+	if err := api.updateGrafanaDatasource(ctx, ds); err != nil {
+		return diag.Errorf("cannot update Grafana data source %s: %s", uid, err)
+	}
+
+	return resourceGrafanaDatasourceRead(ctx, d, m)
+}
+
+func resourceGrafanaDatasourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, uid := splitResourceID3(d.Id())
+
+	api, err := grafanaServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	_, err = api.do(ctx, http.MethodDelete, "/api/datasources/uid/"+url.PathEscape(uid), "", nil)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete Grafana data source %s: %s", uid, err)
+	}
+
+	return nil
+}
+
+type grafanaDatasource struct {
+	ID             int                    `json:"id,omitempty"`
+	UID            string                 `json:"uid,omitempty"`
+	Name           string                 `json:"name"`
+	Type           string                 `json:"type"`
+	URL            string                 `json:"url"`
+	Access         string                 `json:"access"`
+	Database       string                 `json:"database,omitempty"`
+	BasicAuth      bool                   `json:"basicAuth"`
+	BasicAuthUser  string                 `json:"basicAuthUser,omitempty"`
+	IsDefault      bool                   `json:"isDefault"`
+	JSONData       map[string]interface{} `json:"jsonData,omitempty"`
+	SecureJSONData map[string]string      `json:"secureJsonData,omitempty"`
+}
+
+func expandGrafanaDatasource(d *schema.ResourceData) (grafanaDatasource, error) {
+	ds := grafanaDatasource{
+		Name:          d.Get("name").(string),
+		Type:          d.Get("type").(string),
+		URL:           d.Get("url").(string),
+		Access:        "proxy",
+		Database:      d.Get("database").(string),
+		BasicAuthUser: d.Get("basic_auth_username").(string),
+		IsDefault:     d.Get("is_default").(bool),
+	}
+
+	ds.BasicAuth = ds.BasicAuthUser != ""
+	if password := d.Get("basic_auth_password").(string); password != "" {
+		ds.SecureJSONData = map[string]string{"basicAuthPassword": password}
+	}
+
+	if v := d.Get("json_data").(string); v != "" {
+		jsonData, err := structure.ExpandJsonFromString(v)
+		if err != nil {
+			return ds, fmt.Errorf("cannot parse json_data: %s", err)
+		}
+		ds.JSONData = jsonData
+	}
+
+	return ds, nil
+}
+
+func flattenGrafanaDatasource(d *schema.ResourceData, ds *grafanaDatasource) error {
+	if err := d.Set("name", ds.Name); err != nil {
+		return err
+	}
+	if err := d.Set("type", ds.Type); err != nil {
+		return err
+	}
+	if err := d.Set("url", ds.URL); err != nil {
+		return err
+	}
+	if err := d.Set("database", ds.Database); err != nil {
+		return err
+	}
+	if err := d.Set("basic_auth_username", ds.BasicAuthUser); err != nil {
+		return err
+	}
+	if err := d.Set("is_default", ds.IsDefault); err != nil {
+		return err
+	}
+	if err := d.Set("datasource_id", ds.ID); err != nil {
+		return err
+	}
+	if err := d.Set("uid", ds.UID); err != nil {
+		return err
+	}
+
+	// Grafana adds its own defaults to the settings, only the configured ones are tracked
+	if v := d.Get("json_data").(string); v != "" {
+		configured, err := structure.ExpandJsonFromString(v)
+		if err != nil {
+			return fmt.Errorf("cannot parse json_data: %s", err)
+		}
+
+		jsonData, err := structure.FlattenJsonToString(configuredGrafanaJSONData(configured, ds.JSONData))
+		if err != nil {
+			return err
+		}
+		if err := d.Set("json_data", jsonData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// configuredGrafanaJSONData keeps the settings of a data source that are configured, a configured
+// setting missing from Grafana is left out so that it shows up in the plan
+func configuredGrafanaJSONData(configured, jsonData map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k := range configured {
+		if v, ok := jsonData[k]; ok {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+func (o *serviceAPI) createGrafanaDatasource(ctx context.Context, ds grafanaDatasource) (*grafanaDatasource, error) {
+	b, err := json.Marshal(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err = o.do(ctx, http.MethodPost, "/api/datasources", "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Datasource grafanaDatasource `json:"datasource"`
+	}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("cannot parse Grafana data source: %s", err)
+	}
+
+	return &r.Datasource, nil
+}
+
+func (o *serviceAPI) getGrafanaDatasource(ctx context.Context, uid string) (*grafanaDatasource, error) {
+	b, err := o.do(ctx, http.MethodGet, "/api/datasources/uid/"+url.PathEscape(uid), "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ds grafanaDatasource
+	if err := json.Unmarshal(b, &ds); err != nil {
+		return nil, fmt.Errorf("cannot parse Grafana data source: %s", err)
+	}
+
+	return &ds, nil
+}
+
+func (o *serviceAPI) updateGrafanaDatasource(ctx context.Context, ds grafanaDatasource) error {
+	b, err := json.Marshal(ds)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.do(ctx, http.MethodPut, "/api/datasources/"+strconv.Itoa(ds.ID), "application/json", bytes.NewReader(b))
+	return err
+}
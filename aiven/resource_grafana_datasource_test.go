@@ -0,0 +1,179 @@
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_grafanaDatasource(t *testing.T) {
+	var stored grafanaDatasource
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, _ := r.BasicAuth(); u != "avnadmin" || p != "secret" {
+			t.Errorf("unexpected credentials %s:%s", u, p)
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/datasources", "PUT /api/datasources/7":
+			b, _ := ioutil.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &stored); err != nil {
+				t.Errorf("cannot parse data source: %s", err)
+			}
+			stored.ID, stored.UID = 7, "m3-prom"
+			b, _ = json.Marshal(map[string]interface{}{"datasource": stored, "id": 7})
+			_, _ = w.Write(b)
+		case "GET /api/datasources/uid/m3-prom":
+			ds := stored
+			ds.SecureJSONData = nil
+			// Grafana adds its own defaults to the settings
+			ds.JSONData = map[string]interface{}{"tlsSkipVerify": false}
+			for k, v := range stored.JSONData {
+				ds.JSONData[k] = v
+			}
+			b, _ := json.Marshal(ds)
+			_, _ = w.Write(b)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	d := schema.TestResourceDataRaw(t, aivenGrafanaDatasourceSchema, map[string]interface{}{
+		"project":             "test-project",
+		"service_name":        "test-grafana",
+		"name":                "m3db",
+		"type":                "prometheus",
+		"url":                 "https://test-m3db.aivencloud.com:12345/api/v1/prom",
+		"basic_auth_username": "avnadmin",
+		"basic_auth_password": "m3-secret",
+		"json_data":           `{"httpMethod":"POST"}`,
+	})
+
+	api, err := newServiceAPI(srv.URL, "avnadmin", "secret", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ds, err := expandGrafanaDatasource(d)
+	if err != nil {
+		t.Fatalf("expandGrafanaDatasource() error = %v", err)
+	}
+
+	ctx := context.Background()
+	created, err := api.createGrafanaDatasource(ctx, ds)
+	if err != nil {
+		t.Fatalf("createGrafanaDatasource() error = %v", err)
+	}
+	if created.ID != 7 || created.UID != "m3-prom" {
+		t.Errorf("createGrafanaDatasource() = %d/%s, want 7/m3-prom", created.ID, created.UID)
+	}
+
+	wantStored := grafanaDatasource{
+		ID:             7,
+		UID:            "m3-prom",
+		Name:           "m3db",
+		Type:           "prometheus",
+		URL:            "https://test-m3db.aivencloud.com:12345/api/v1/prom",
+		Access:         "proxy",
+		BasicAuth:      true,
+		BasicAuthUser:  "avnadmin",
+		JSONData:       map[string]interface{}{"httpMethod": "POST"},
+		SecureJSONData: map[string]string{"basicAuthPassword": "m3-secret"},
+	}
+	if !reflect.DeepEqual(stored, wantStored) {
+		t.Errorf("createGrafanaDatasource() sent %+v, want %+v", stored, wantStored)
+	}
+
+	read, err := api.getGrafanaDatasource(ctx, "m3-prom")
+	if err != nil {
+		t.Fatalf("getGrafanaDatasource() error = %v", err)
+	}
+	if err := flattenGrafanaDatasource(d, read); err != nil {
+		t.Fatalf("flattenGrafanaDatasource() error = %v", err)
+	}
+	if d.Get("datasource_id").(int) != 7 || d.Get("uid").(string) != "m3-prom" {
+		t.Errorf("flattenGrafanaDatasource() id = %v/%v, want 7/m3-prom", d.Get("datasource_id"), d.Get("uid"))
+	}
+	if d.Get("basic_auth_password").(string) != "m3-secret" {
+		t.Error("flattenGrafanaDatasource() must keep the password, Grafana does not return it")
+	}
+	if got := d.Get("json_data").(string); got != `{"httpMethod":"POST"}` {
+		t.Errorf("flattenGrafanaDatasource() json_data = %s, want only the configured settings", got)
+	}
+}
+
+func TestAccAivenGrafanaDatasource_basic(t *testing.T) {
+	resourceName := "aiven_grafana_datasource.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrafanaDatasourceResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-grafana-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "name", "m3db"),
+					resource.TestCheckResourceAttr(resourceName, "type", "prometheus"),
+					resource.TestCheckResourceAttrSet(resourceName, "datasource_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "uid"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGrafanaDatasourceResource(name string) string {
+	return fmt.Sprintf(`
+    data "aiven_project" "foo" {
+      project = "%s"
+    }
+
+    resource "aiven_grafana" "bar" {
+      project = data.aiven_project.foo.project
+      cloud_name = "google-europe-west1"
+      plan = "startup-1"
+      service_name = "test-acc-sr-grafana-%s"
+      maintenance_window_dow = "monday"
+      maintenance_window_time = "10:00:00"
+    }
+
+    resource "aiven_m3db" "bar" {
+      project = data.aiven_project.foo.project
+      cloud_name = "google-europe-west1"
+      plan = "business-8"
+      service_name = "test-acc-sr-m3db-%s"
+      maintenance_window_dow = "monday"
+      maintenance_window_time = "10:00:00"
+
+      m3db_user_config {
+        namespaces {
+          name = "default"
+          type = "unaggregated"
+        }
+      }
+    }
+
+    resource "aiven_grafana_datasource" "foo" {
+      project = data.aiven_project.foo.project
+      service_name = aiven_grafana.bar.service_name
+      name = "m3db"
+      type = "prometheus"
+      url = "https://${aiven_m3db.bar.service_host}:${aiven_m3db.bar.service_port}/api/v1/prom"
+      basic_auth_username = aiven_m3db.bar.service_username
+      basic_auth_password = aiven_m3db.bar.service_password
+    }`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
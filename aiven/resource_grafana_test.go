@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccAiven_grafana(t *testing.T) {
@@ -70,3 +71,56 @@ func testAccGrafanaResource(name string) string {
 		}
 		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
 }
+
+func TestAccAiven_grafana_rotateAdminPassword(t *testing.T) {
+	resourceName := "aiven_grafana.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	var password string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrafanaRotateAdminPasswordResource(rName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "grafana_admin_password"),
+					func(s *terraform.State) error {
+						password = s.RootModule().Resources[resourceName].Primary.Attributes["grafana_admin_password"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccGrafanaRotateAdminPasswordResource(rName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "grafana_admin_password"),
+					func(s *terraform.State) error {
+						rotated := s.RootModule().Resources[resourceName].Primary.Attributes["grafana_admin_password"]
+						if rotated == password {
+							return fmt.Errorf("grafana admin password was not rotated")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccGrafanaRotateAdminPasswordResource(name, rotation string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_grafana" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-1"
+			service_name = "test-acc-sr-%s"
+			rotate_admin_password = "%s"
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, rotation)
+}
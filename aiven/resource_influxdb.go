@@ -37,9 +37,11 @@ func resourceInfluxDB() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeInfluxDB),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: influxDBSchema(),
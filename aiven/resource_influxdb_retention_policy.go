@@ -0,0 +1,369 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenInfluxDBRetentionPolicySchema = map[string]*schema.Schema{
+	"project":      commonSchemaProjectReference,
+	"service_name": commonSchemaServiceNameReference,
+	"database": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("Name of the database the retention policy belongs to.").referenced().forceNew().build(),
+	},
+	"name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  complex("Name of the retention policy.").forceNew().build(),
+	},
+	"duration": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validateInfluxDBDuration,
+		DiffSuppressFunc: influxDBDurationDiffSuppressFunc,
+		Description:      "How long the data is kept, as an InfluxQL duration such as `30d` or `720h`, `INF` keeps the data forever",
+	},
+	"replication": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      1,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  complex("Number of copies of each point stored in the cluster.").defaultValue(1).build(),
+	},
+	"default": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: complex("Use the retention policy for the writes that do not name one. A database has a " +
+			"single default policy, marking a policy as default unmarks the previous one and the default cannot " +
+			"be unset other than by marking another policy as default.").defaultValue(false).build(),
+	},
+}
+
+func resourceInfluxDBRetentionPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The InfluxDB Retention Policy resource allows the creation and management of the retention policies of the databases of an Aiven InfluxDB service. The retention policies are managed through the InfluxDB API of the service with its admin credentials, so the service must be reachable from where Terraform runs.",
+		CreateContext: resourceInfluxDBRetentionPolicyCreate,
+		ReadContext:   resourceInfluxDBRetentionPolicyRead,
+		UpdateContext: resourceInfluxDBRetentionPolicyUpdate,
+		DeleteContext: resourceInfluxDBRetentionPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenInfluxDBRetentionPolicySchema,
+	}
+}
+
+func influxDBServiceAPI(client *aiven.Client, project, serviceName string) (*serviceAPI, error) {
+	service, err := getServiceOfType(client, project, serviceName, ServiceTypeInfluxDB)
+	if err != nil {
+		return nil, err
+	}
+
+	// the service URI has an InfluxDB specific scheme, the HTTP API is served on the same host and port
+	host, port := serviceHostPort(service)
+	u := url.URL{Scheme: "https", Host: fmt.Sprintf("%s:%d", host, port)}
+
+	return newProjectServiceAPI(client, project, service, u.String())
+}
+
+func resourceInfluxDBRetentionPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	database := d.Get("database").(string)
+	name := d.Get("name").(string)
+
+	api, err := influxDBServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	q := influxDBRetentionPolicyStatement("CREATE", database, name,
+		d.Get("duration").(string), d.Get("replication").(int), d.Get("default").(bool))
+	if _, err := api.influxDBQuery(ctx, q); err != nil {
+		return diag.Errorf("cannot create retention policy %s on database %s: %s", name, database, err)
+	}
+
+	d.SetId(buildResourceID(project, serviceName, database, name))
+
+	return resourceInfluxDBRetentionPolicyRead(ctx, d, m)
+}
+
+func resourceInfluxDBRetentionPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, database, name := splitResourceID4(d.Id())
+
+	api, err := influxDBServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	policy, err := api.getInfluxDBRetentionPolicy(ctx, database, name)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("duration", policy.Duration); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("replication", policy.Replication); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("default", policy.Default); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceInfluxDBRetentionPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, database, name := splitResourceID4(d.Id())
+
+	api, err := influxDBServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// InfluxDB only moves the default to another policy, check that another one took it over
+	if o, n := d.GetChange("default"); o.(bool) && !n.(bool) {
+		policy, err := api.getInfluxDBRetentionPolicy(ctx, database, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if policy.Default {
+			return diag.Errorf("retention policy %s is the default of database %s, mark another retention "+
+				"policy of the database as default instead of unsetting it", name, database)
+		}
+	}
+
+	q := influxDBRetentionPolicyStatement("ALTER", database, name,
+		d.Get("duration").(string), d.Get("replication").(int), d.Get("default").(bool))
+	if _, err := api.influxDBQuery(ctx, q); err != nil {
+		return diag.Errorf("cannot update retention policy %s on database %s: %s", name, database, err)
+	}
+
+	return resourceInfluxDBRetentionPolicyRead(ctx, d, m)
+}
+
+func resourceInfluxDBRetentionPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, database, name := splitResourceID4(d.Id())
+
+	api, err := influxDBServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	q := fmt.Sprintf("DROP RETENTION POLICY %s ON %s", influxDBQuoteIdent(name), influxDBQuoteIdent(database))
+	if _, err := api.influxDBQuery(ctx, q); err != nil {
+		return diag.Errorf("cannot delete retention policy %s on database %s: %s", name, database, err)
+	}
+
+	return nil
+}
+
+type influxDBRetentionPolicy struct {
+	Name        string
+	Duration    string
+	Replication int
+	Default     bool
+}
+
+type influxDBSeries struct {
+	Columns []string        `json:"columns"`
+	Values  [][]interface{} `json:"values"`
+}
+
+// influxDBRetentionPolicyStatement builds a CREATE or ALTER RETENTION POLICY statement
+func influxDBRetentionPolicyStatement(verb, database, name, duration string, replication int, isDefault bool) string {
+	q := fmt.Sprintf("%s RETENTION POLICY %s ON %s DURATION %s REPLICATION %d",
+		verb, influxDBQuoteIdent(name), influxDBQuoteIdent(database), duration, replication)
+	if isDefault {
+		q += " DEFAULT"
+	}
+
+	return q
+}
+
+func influxDBQuoteIdent(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// influxDBQuery runs a single InfluxQL statement, the errors of the statement are reported
+// in the response body of a successful request
+func (o *serviceAPI) influxDBQuery(ctx context.Context, q string) ([]influxDBSeries, error) {
+	b, err := o.do(ctx, http.MethodPost, "/query", "application/x-www-form-urlencoded",
+		strings.NewReader(url.Values{"q": {q}}.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Results []struct {
+			Series []influxDBSeries `json:"series"`
+			Error  string           `json:"error"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("cannot parse InfluxDB response: %s", err)
+	}
+	if len(r.Results) == 0 {
+		return nil, nil
+	}
+	if r.Results[0].Error != "" {
+		// the API of the statements is not resource based, report missing databases as not found
+		status := http.StatusBadRequest
+		if strings.Contains(r.Results[0].Error, "database not found") {
+			status = http.StatusNotFound
+		}
+		return nil, aiven.Error{Message: r.Results[0].Error, Status: status}
+	}
+
+	return r.Results[0].Series, nil
+}
+
+func (o *serviceAPI) getInfluxDBRetentionPolicy(ctx context.Context, database, name string) (*influxDBRetentionPolicy, error) {
+	series, err := o.influxDBQuery(ctx, "SHOW RETENTION POLICIES ON "+influxDBQuoteIdent(database))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range series {
+		for _, p := range parseInfluxDBRetentionPolicies(s) {
+			if p.Name == name {
+				return p, nil
+			}
+		}
+	}
+
+	return nil, aiven.Error{Message: fmt.Sprintf("retention policy %s not found", name), Status: http.StatusNotFound}
+}
+
+// parseInfluxDBRetentionPolicies converts the rows of SHOW RETENTION POLICIES using their
+// column names
+func parseInfluxDBRetentionPolicies(s influxDBSeries) []*influxDBRetentionPolicy {
+	var policies []*influxDBRetentionPolicy
+	for _, row := range s.Values {
+		p := &influxDBRetentionPolicy{}
+		for i, c := range s.Columns {
+			if i >= len(row) {
+				break
+			}
+			switch c {
+			case "name":
+				p.Name, _ = row[i].(string)
+			case "duration":
+				p.Duration, _ = row[i].(string)
+			case "replicaN":
+				if v, ok := row[i].(float64); ok {
+					p.Replication = int(v)
+				}
+			case "default":
+				p.Default, _ = row[i].(bool)
+			}
+		}
+		policies = append(policies, p)
+	}
+
+	return policies
+}
+
+var influxDBDurationUnitRegexp = regexp.MustCompile(`(\d+)(ns|us|u|µ|ms|s|m|h|d|w)`)
+
+// parseInfluxDBDuration parses an InfluxQL duration literal, e.g. `30d`, `1h30m` or the
+// `720h0m0s` returned by InfluxDB, an infinite duration is zero
+func parseInfluxDBDuration(s string) (time.Duration, error) {
+	if strings.EqualFold(s, "INF") {
+		return 0, nil
+	}
+
+	units := map[string]time.Duration{
+		"ns": time.Nanosecond,
+		"us": time.Microsecond,
+		"u":  time.Microsecond,
+		"µ":  time.Microsecond,
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+	}
+
+	matches := influxDBDurationUnitRegexp.FindAllStringSubmatch(s, -1)
+	var matched strings.Builder
+	var d time.Duration
+	for _, m := range matches {
+		matched.WriteString(m[0])
+		n, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * units[m[2]]
+	}
+	if len(matches) == 0 || matched.String() != s {
+		return 0, fmt.Errorf("invalid InfluxDB duration %q", s)
+	}
+
+	return d, nil
+}
+
+func validateInfluxDBDuration(v interface{}, k string) ([]string, []error) {
+	if _, err := parseInfluxDBDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+
+	return nil, nil
+}
+
+// influxDBDurationDiffSuppressFunc compares durations by their value, InfluxDB reports
+// `30d` as `720h0m0s`
+func influxDBDurationDiffSuppressFunc(_, old, new string, _ *schema.ResourceData) bool {
+	o, err := parseInfluxDBDuration(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseInfluxDBDuration(new)
+	if err != nil {
+		return false
+	}
+
+	return o == n
+}
@@ -0,0 +1,156 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_parseInfluxDBDuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"720h0m0s", 30 * 24 * time.Hour, false},
+		{"1w2d", 9 * 24 * time.Hour, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"INF", 0, false},
+		{"0s", 0, false},
+		{"30 days", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration, func(t *testing.T) {
+			got, err := parseInfluxDBDuration(tt.duration)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseInfluxDBDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseInfluxDBDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_influxDBRetentionPolicy(t *testing.T) {
+	var statements []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/query" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		q := r.FormValue("q")
+		statements = append(statements, q)
+		switch q {
+		case `SHOW RETENTION POLICIES ON "metrics"`:
+			_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"series":[{` +
+				`"columns":["name","duration","shardGroupDuration","replicaN","default"],` +
+				`"values":[["autogen","0s","168h0m0s",1,false],["month","720h0m0s","24h0m0s",1,true]]}]}]}`))
+		case `SHOW RETENTION POLICIES ON "missing"`:
+			_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"error":"database not found: missing"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"results":[{"statement_id":0}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	api, err := newServiceAPI(srv.URL, "avnadmin", "secret", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	q := influxDBRetentionPolicyStatement("CREATE", "metrics", "month", "30d", 1, true)
+	if _, err := api.influxDBQuery(ctx, q); err != nil {
+		t.Fatalf("influxDBQuery() error = %v", err)
+	}
+	if want := `CREATE RETENTION POLICY "month" ON "metrics" DURATION 30d REPLICATION 1 DEFAULT`; statements[0] != want {
+		t.Errorf("statement = %s, want %s", statements[0], want)
+	}
+
+	policy, err := api.getInfluxDBRetentionPolicy(ctx, "metrics", "month")
+	if err != nil {
+		t.Fatalf("getInfluxDBRetentionPolicy() error = %v", err)
+	}
+	want := influxDBRetentionPolicy{Name: "month", Duration: "720h0m0s", Replication: 1, Default: true}
+	if *policy != want {
+		t.Errorf("getInfluxDBRetentionPolicy() = %+v, want %+v", *policy, want)
+	}
+	if !influxDBDurationDiffSuppressFunc("duration", policy.Duration, "30d", nil) {
+		t.Errorf("duration %s should match 30d", policy.Duration)
+	}
+
+	if _, err := api.getInfluxDBRetentionPolicy(ctx, "metrics", "year"); !aiven.IsNotFound(err) {
+		t.Errorf("getInfluxDBRetentionPolicy() of a missing policy error = %v, want not found", err)
+	}
+	if _, err := api.getInfluxDBRetentionPolicy(ctx, "missing", "month"); !aiven.IsNotFound(err) {
+		t.Errorf("getInfluxDBRetentionPolicy() of a missing database error = %v, want not found", err)
+	}
+}
+
+func TestAccAivenInfluxDBRetentionPolicy_basic(t *testing.T) {
+	resourceName := "aiven_influxdb_retention_policy.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInfluxDBRetentionPolicyResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "database", "test_acc_db"),
+					resource.TestCheckResourceAttr(resourceName, "name", "thirty_days"),
+					resource.TestCheckResourceAttr(resourceName, "duration", "720h0m0s"),
+					resource.TestCheckResourceAttr(resourceName, "replication", "1"),
+					resource.TestCheckResourceAttr(resourceName, "default", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInfluxDBRetentionPolicyResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_influxdb" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+		}
+
+		resource "aiven_database" "foo" {
+			project = aiven_influxdb.bar.project
+			service_name = aiven_influxdb.bar.service_name
+			database_name = "test_acc_db"
+		}
+
+		resource "aiven_influxdb_retention_policy" "foo" {
+			project = aiven_database.foo.project
+			service_name = aiven_database.foo.service_name
+			database = aiven_database.foo.database_name
+			name = "thirty_days"
+			duration = "30d"
+			default = true
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
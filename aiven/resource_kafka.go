@@ -7,6 +7,7 @@ import (
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func aivenKafkaSchema() map[string]*schema.Schema {
@@ -17,6 +18,11 @@ func aivenKafkaSchema() map[string]*schema.Schema {
 		Default:     true,
 		Description: "Create default wildcard Kafka ACL",
 	}
+	aivenKafkaSchema["kafka_acl_default"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Default ACL posture of the Kafka service. `allow_all` when the default wildcard ACL exists, `deny` otherwise.",
+	}
 	aivenKafkaSchema[ServiceTypeKafka] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -64,6 +70,9 @@ func aivenKafkaSchema() map[string]*schema.Schema {
 		},
 	}
 	aivenKafkaSchema[ServiceTypeKafka+"_user_config"] = generateServiceUserConfiguration(ServiceTypeKafka)
+	for k, v := range kafkaBrokerSettingsSchema() {
+		aivenKafkaSchema[k] = v
+	}
 
 	return aivenKafkaSchema
 }
@@ -78,9 +87,11 @@ func resourceKafka() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeKafka),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: aivenKafkaSchema(),
@@ -94,7 +105,7 @@ func resourceKafkaCreate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	// if default_acl=false delete default wildcard Kafka ACL that is automatically created
 	if !d.Get("default_acl").(bool) {
-		client := m.(*aiven.Client)
+		client := m.(*providerMeta).client
 		project := d.Get("project").(string)
 		serviceName := d.Get("service_name").(string)
 
@@ -106,13 +117,109 @@ func resourceKafkaCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 
 		for _, acl := range list {
-			if acl.Username == "*" && acl.Topic == "*" && acl.Permission == "admin" {
+			if isKafkaDefaultACL(acl) {
 				err := client.KafkaACLs.Delete(project, serviceName, acl.ID)
 				if err != nil {
 					return diag.Errorf("cannot delete default wildcard kafka acl: %s", err)
 				}
 			}
 		}
+
+		if err := d.Set("kafka_acl_default", "deny"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// isKafkaDefaultACL checks if an ACL is the wildcard one created together with the service
+func isKafkaDefaultACL(acl *aiven.KafkaACL) bool {
+	return acl.Username == "*" && acl.Topic == "*" && acl.Permission == "admin"
+}
+
+// kafkaACLDefault describes the default ACL posture of a Kafka service
+func kafkaACLDefault(r *aiven.Service) string {
+	for _, acl := range r.ACL {
+		if isKafkaDefaultACL(acl) {
+			return "allow_all"
+		}
+	}
+
+	return "deny"
+}
+
+// kafkaBrokerSettings maps the top-level Kafka broker fields to their
+// kafka_user_config.kafka key
+var kafkaBrokerSettings = map[string]string{
+	"kafka_message_max_bytes":       "message_max_bytes",
+	"kafka_replica_fetch_max_bytes": "replica_fetch_max_bytes",
+	"kafka_num_partitions":          "num_partitions",
+}
+
+func kafkaBrokerSettingsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"kafka_message_max_bytes": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.IntBetween(0, 100001200),
+			ConflictsWith: []string{"kafka_user_config.0.kafka.0.message_max_bytes"},
+			Description:   "Maximum size in bytes of a message the brokers accept, between 0 and 100001200. Shorthand for `kafka_user_config.kafka.message_max_bytes`.",
+		},
+		"kafka_replica_fetch_max_bytes": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.IntBetween(1048576, 104857600),
+			ConflictsWith: []string{"kafka_user_config.0.kafka.0.replica_fetch_max_bytes"},
+			Description:   "Number of bytes of messages the replicas try to fetch for each partition, between 1048576 and 104857600. Raise it together with `kafka_message_max_bytes` so that the replicas keep up with large messages. Shorthand for `kafka_user_config.kafka.replica_fetch_max_bytes`.",
+		},
+		"kafka_num_partitions": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.IntBetween(1, 1000),
+			ConflictsWith: []string{"kafka_user_config.0.kafka.0.num_partitions"},
+			Description:   "Number of partitions of the automatically created topics, between 1 and 1000. Shorthand for `kafka_user_config.kafka.num_partitions`.",
+		},
+	}
+}
+
+// expandKafkaBrokerSettings adds the top-level Kafka broker fields that are set to the
+// nested kafka settings of the user config sent to the API, see userConfigShorthand
+func expandKafkaBrokerSettings(d *schema.ResourceData, userConfig map[string]interface{}) map[string]interface{} {
+	for field, key := range kafkaBrokerSettings {
+		v, ok := userConfigShorthand(d, field)
+		if !ok {
+			continue
+		}
+		if userConfig == nil {
+			userConfig = make(map[string]interface{})
+		}
+		broker, ok := userConfig["kafka"].(map[string]interface{})
+		if !ok {
+			broker = make(map[string]interface{})
+			userConfig["kafka"] = broker
+		}
+		broker[key] = v
+	}
+
+	return userConfig
+}
+
+// flattenKafkaBrokerSettings sets the top-level Kafka broker fields from the service user
+// config
+func flattenKafkaBrokerSettings(d *schema.ResourceData, userConfig map[string]interface{}) error {
+	broker, _ := userConfig["kafka"].(map[string]interface{})
+	for field, key := range kafkaBrokerSettings {
+		var v interface{}
+		if f, ok := broker[key].(float64); ok {
+			v = int(f)
+		}
+		if err := d.Set(field, v); err != nil {
+			return err
+		}
 	}
 
 	return nil
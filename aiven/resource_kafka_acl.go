@@ -54,7 +54,7 @@ func resourceKafkaACL() *schema.Resource {
 }
 
 func resourceKafkaACLCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -78,7 +78,7 @@ func resourceKafkaACLCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceKafkaACLRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, aclID := splitResourceID3(d.Id())
 	acl, err := cache.ACLCache{}.Read(project, serviceName, aclID, client)
@@ -95,7 +95,7 @@ func resourceKafkaACLRead(_ context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceKafkaACLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, aclID := splitResourceID3(d.Id())
 	err := client.KafkaACLs.Delete(projectName, serviceName, aclID)
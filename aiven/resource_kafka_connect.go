@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func aivenKafkaConnectSchema() map[string]*schema.Schema {
@@ -17,6 +18,9 @@ func aivenKafkaConnectSchema() map[string]*schema.Schema {
 		},
 	}
 	kafkaConnectSchema[ServiceTypeKafkaConnect+"_user_config"] = generateServiceUserConfiguration(ServiceTypeKafkaConnect)
+	for k, v := range kafkaConnectWorkerSettingsSchema() {
+		kafkaConnectSchema[k] = v
+	}
 
 	return kafkaConnectSchema
 }
@@ -31,11 +35,81 @@ func resourceKafkaConnect() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeKafkaConnect),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: aivenKafkaConnectSchema(),
 	}
 }
+
+// kafkaConnectWorkerSettings maps the top-level Kafka Connect worker fields to their
+// kafka_connect_user_config.kafka_connect key
+var kafkaConnectWorkerSettings = map[string]string{
+	"connect_offset_flush_interval_ms": "offset_flush_interval_ms",
+	"consumer_max_poll_records":        "consumer_max_poll_records",
+}
+
+func kafkaConnectWorkerSettingsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"connect_offset_flush_interval_ms": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.IntBetween(1, 100000000),
+			ConflictsWith: []string{"kafka_connect_user_config.0.kafka_connect.0.offset_flush_interval_ms"},
+			Description:   "Interval in milliseconds at which the workers try committing the offsets of the tasks, between 1 and 100000000. Shorthand for `kafka_connect_user_config.kafka_connect.offset_flush_interval_ms`.",
+		},
+		"consumer_max_poll_records": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.IntBetween(1, 10000),
+			ConflictsWith: []string{"kafka_connect_user_config.0.kafka_connect.0.consumer_max_poll_records"},
+			Description:   "Maximum number of records returned by a single poll of the sink connector consumers, between 1 and 10000. Shorthand for `kafka_connect_user_config.kafka_connect.consumer_max_poll_records`.",
+		},
+	}
+}
+
+// expandKafkaConnectWorkerSettings adds the top-level Kafka Connect worker fields that are
+// set to the nested kafka_connect settings of the user config sent to the API, see
+// userConfigShorthand
+func expandKafkaConnectWorkerSettings(d *schema.ResourceData, userConfig map[string]interface{}) map[string]interface{} {
+	for field, key := range kafkaConnectWorkerSettings {
+		v, ok := userConfigShorthand(d, field)
+		if !ok {
+			continue
+		}
+		if userConfig == nil {
+			userConfig = make(map[string]interface{})
+		}
+		worker, ok := userConfig["kafka_connect"].(map[string]interface{})
+		if !ok {
+			worker = make(map[string]interface{})
+			userConfig["kafka_connect"] = worker
+		}
+		worker[key] = v
+	}
+
+	return userConfig
+}
+
+// flattenKafkaConnectWorkerSettings sets the top-level Kafka Connect worker fields from the
+// service user config
+func flattenKafkaConnectWorkerSettings(d *schema.ResourceData, userConfig map[string]interface{}) error {
+	worker, _ := userConfig["kafka_connect"].(map[string]interface{})
+	for field, key := range kafkaConnectWorkerSettings {
+		var v interface{}
+		if f, ok := worker[key].(float64); ok {
+			v = int(f)
+		}
+		if err := d.Set(field, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
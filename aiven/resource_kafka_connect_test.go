@@ -3,12 +3,139 @@ package aiven
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func Test_expandKafkaConnectWorkerSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			"offset flush interval",
+			map[string]interface{}{"connect_offset_flush_interval_ms": 10000},
+			nil,
+			map[string]interface{}{
+				"kafka_connect": map[string]interface{}{"offset_flush_interval_ms": 10000},
+			},
+		},
+		{
+			"merged with the nested settings",
+			map[string]interface{}{"consumer_max_poll_records": 100},
+			map[string]interface{}{
+				"kafka_connect": map[string]interface{}{"consumer_isolation_level": "read_committed"},
+			},
+			map[string]interface{}{
+				"kafka_connect": map[string]interface{}{
+					"consumer_isolation_level":  "read_committed",
+					"consumer_max_poll_records": 100,
+				},
+			},
+		},
+		{
+			"unset",
+			map[string]interface{}{},
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, aivenKafkaConnectSchema(), tt.raw)
+			if got := expandKafkaConnectWorkerSettings(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandKafkaConnectWorkerSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expandKafkaConnectWorkerSettingsUpdate(t *testing.T) {
+	state := map[string]string{
+		"project":                                     "test-project",
+		"service_name":                                "test-service",
+		"connect_offset_flush_interval_ms":            "10000",
+		"consumer_max_poll_records":                   "100",
+		"kafka_connect_user_config.#":                 "1",
+		"kafka_connect_user_config.0.kafka_connect.#": "1",
+		"kafka_connect_user_config.0.kafka_connect.0.offset_flush_interval_ms":  "10000",
+		"kafka_connect_user_config.0.kafka_connect.0.consumer_max_poll_records": "100",
+	}
+
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			// the fields keep the values read back, which must not override the nested keys
+			"nested key changed",
+			map[string]interface{}{
+				"project":      "test-project",
+				"service_name": "test-service",
+				"kafka_connect_user_config": []interface{}{map[string]interface{}{
+					"kafka_connect": []interface{}{map[string]interface{}{
+						"offset_flush_interval_ms":  "20000",
+						"consumer_max_poll_records": "100",
+					}},
+				}},
+			},
+			map[string]interface{}{
+				"kafka_connect": map[string]interface{}{"offset_flush_interval_ms": 20000, "consumer_max_poll_records": 100},
+			},
+			map[string]interface{}{
+				"kafka_connect": map[string]interface{}{"offset_flush_interval_ms": 20000, "consumer_max_poll_records": 100},
+			},
+		},
+		{
+			"field changed",
+			map[string]interface{}{
+				"project":                          "test-project",
+				"service_name":                     "test-service",
+				"connect_offset_flush_interval_ms": 20000,
+			},
+			nil,
+			map[string]interface{}{
+				"kafka_connect": map[string]interface{}{"offset_flush_interval_ms": 20000},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testResourceDataUpdate(t, resourceKafkaConnect(), state, tt.raw)
+			if got := expandKafkaConnectWorkerSettings(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandKafkaConnectWorkerSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flattenKafkaConnectWorkerSettings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenKafkaConnectSchema(), map[string]interface{}{})
+	err := flattenKafkaConnectWorkerSettings(d, map[string]interface{}{
+		"kafka_connect": map[string]interface{}{
+			"offset_flush_interval_ms":  float64(10000),
+			"consumer_max_poll_records": float64(100),
+		},
+	})
+	if err != nil {
+		t.Fatalf("flattenKafkaConnectWorkerSettings() error = %v", err)
+	}
+	if got := d.Get("connect_offset_flush_interval_ms"); got != 10000 {
+		t.Errorf("connect_offset_flush_interval_ms = %v, want 10000", got)
+	}
+	if got := d.Get("consumer_max_poll_records"); got != 100 {
+		t.Errorf("consumer_max_poll_records = %v, want 100", got)
+	}
+}
+
 // Kafka Connect service tests
 func TestAccAiven_kafkaconnect(t *testing.T) {
 	resourceName := "aiven_kafka_connect.bar"
@@ -72,3 +199,44 @@ func testAccKafkaConnectResource(name string) string {
 		}
 		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
 }
+
+func TestAccAiven_kafkaconnectWorkerSettings(t *testing.T) {
+	resourceName := "aiven_kafka_connect.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaConnectWorkerSettingsResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "connect_offset_flush_interval_ms", "10000"),
+					resource.TestCheckResourceAttr(resourceName, "consumer_max_poll_records", "100"),
+					resource.TestCheckResourceAttr(resourceName, "kafka_connect_user_config.0.kafka_connect.0.offset_flush_interval_ms", "10000"),
+					resource.TestCheckResourceAttr(resourceName, "kafka_connect_user_config.0.kafka_connect.0.consumer_max_poll_records", "100"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaConnectWorkerSettingsResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka_connect" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+			connect_offset_flush_interval_ms = 10000
+			consumer_max_poll_records = 100
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
@@ -85,7 +85,7 @@ func resourceKafkaSchema() *schema.Resource {
 }
 
 func kafkaSchemaSubjectGetLastVersion(m interface{}, project, serviceName, subjectName string) (int, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	r, err := client.KafkaSubjectSchemas.GetVersions(project, serviceName, subjectName)
 	if err != nil {
@@ -111,7 +111,7 @@ func resourceKafkaSchemaCreate(ctx context.Context, d *schema.ResourceData, m in
 	serviceName := d.Get("service_name").(string)
 	subjectName := d.Get("subject_name").(string)
 
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	// create Kafka Schema Subject
 	_, err := client.KafkaSubjectSchemas.Add(
@@ -156,7 +156,7 @@ func resourceKafkaSchemaCreate(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceKafkaSchemaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var project, serviceName, subjectName = splitResourceID3(d.Id())
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if d.HasChange("schema") {
 		_, err := client.KafkaSubjectSchemas.Add(
@@ -190,7 +190,7 @@ func resourceKafkaSchemaUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceKafkaSchemaRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var project, serviceName, subjectName = splitResourceID3(d.Id())
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	version, err := kafkaSchemaSubjectGetLastVersion(m, project, serviceName, subjectName)
 	if err != nil {
@@ -238,7 +238,7 @@ func resourceKafkaSchemaRead(_ context.Context, d *schema.ResourceData, m interf
 func resourceKafkaSchemaDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var project, serviceName, schemaName = splitResourceID3(d.Id())
 
-	err := m.(*aiven.Client).KafkaSubjectSchemas.Delete(project, serviceName, schemaName)
+	err := m.(*providerMeta).client.KafkaSubjectSchemas.Delete(project, serviceName, schemaName)
 	if err != nil && !aiven.IsNotFound(err) {
 		return diag.FromErr(err)
 	}
@@ -55,7 +55,7 @@ func resourceKafkaSchemaConfiguration() *schema.Resource {
 func resourceKafkaSchemaConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	project, serviceName := splitResourceID2(d.Id())
 
-	_, err := m.(*aiven.Client).KafkaGlobalSchemaConfig.Update(
+	_, err := m.(*providerMeta).client.KafkaGlobalSchemaConfig.Update(
 		project,
 		serviceName,
 		aiven.KafkaSchemaConfig{
@@ -73,7 +73,7 @@ func resourceKafkaSchemaConfigurationCreate(ctx context.Context, d *schema.Resou
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 
-	_, err := m.(*aiven.Client).KafkaGlobalSchemaConfig.Update(
+	_, err := m.(*providerMeta).client.KafkaGlobalSchemaConfig.Update(
 		project,
 		serviceName,
 		aiven.KafkaSchemaConfig{
@@ -91,7 +91,7 @@ func resourceKafkaSchemaConfigurationCreate(ctx context.Context, d *schema.Resou
 func resourceKafkaSchemaConfigurationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	project, serviceName := splitResourceID2(d.Id())
 
-	r, err := m.(*aiven.Client).KafkaGlobalSchemaConfig.Get(project, serviceName)
+	r, err := m.(*providerMeta).client.KafkaGlobalSchemaConfig.Get(project, serviceName)
 	if err != nil {
 		return diag.FromErr(resourceReadHandleNotFound(err, d))
 	}
@@ -114,7 +114,7 @@ func resourceKafkaSchemaConfigurationRead(_ context.Context, d *schema.ResourceD
 func resourceKafkaSchemaConfigurationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	project, serviceName := splitResourceID2(d.Id())
 
-	_, err := m.(*aiven.Client).KafkaGlobalSchemaConfig.Update(
+	_, err := m.(*providerMeta).client.KafkaGlobalSchemaConfig.Update(
 		project,
 		serviceName,
 		aiven.KafkaSchemaConfig{
@@ -3,10 +3,13 @@ package aiven
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
+	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccAiven_kafka(t *testing.T) {
@@ -22,23 +25,27 @@ func TestAccAiven_kafka(t *testing.T) {
 			{
 				Config: testAccKafkaResource(rName),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAivenServiceRunning(t, resourceName),
 					testAccCheckAivenServiceCommonAttributes("data.aiven_kafka.service"),
 					testAccCheckAivenServiceKafkaAttributes("data.aiven_kafka.service"),
 					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
 					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
 					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
 					resource.TestCheckResourceAttr(resourceName, "service_type", "kafka"),
+					resource.TestCheckResourceAttrPair(resourceName, "env_vars.KAFKA_BOOTSTRAP_SERVERS", resourceName, "service_uri"),
 					resource.TestCheckResourceAttr(resourceName, "cloud_name", "google-europe-west1"),
 					resource.TestCheckResourceAttr(resourceName, "maintenance_window_dow", "monday"),
 					resource.TestCheckResourceAttr(resourceName, "maintenance_window_time", "10:00:00"),
 					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
 					resource.TestCheckResourceAttr(resourceName, "termination_protection", "false"),
 					resource.TestCheckResourceAttr(resourceName, "default_acl", "true"),
+					resource.TestCheckResourceAttr(resourceName, "kafka_acl_default", "allow_all"),
 				),
 			},
 			{
 				Config: testAccKafkaWithoutDefaultACLResource(rName2),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAivenServiceRunning(t, resourceName),
 					testAccCheckAivenServiceCommonAttributes("data.aiven_kafka.service"),
 					testAccCheckAivenServiceKafkaAttributes("data.aiven_kafka.service"),
 					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName2)),
@@ -51,12 +58,240 @@ func TestAccAiven_kafka(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
 					resource.TestCheckResourceAttr(resourceName, "termination_protection", "false"),
 					resource.TestCheckResourceAttr(resourceName, "default_acl", "false"),
+					resource.TestCheckResourceAttr(resourceName, "kafka_acl_default", "deny"),
 				),
 			},
 		},
 	})
 }
 
+func Test_kafkaACLDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *aiven.Service
+		want string
+	}{
+		{
+			"default wildcard acl",
+			&aiven.Service{ACL: []*aiven.KafkaACL{
+				{ID: "default", Permission: "admin", Topic: "*", Username: "*"},
+			}},
+			"allow_all",
+		},
+		{
+			"explicit acls only",
+			&aiven.Service{ACL: []*aiven.KafkaACL{
+				{ID: "acl1", Permission: "read", Topic: "logs", Username: "consumer"},
+			}},
+			"deny",
+		},
+		{
+			"no acls",
+			&aiven.Service{},
+			"deny",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kafkaACLDefault(tt.r); got != tt.want {
+				t.Errorf("kafkaACLDefault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expandKafkaBrokerSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			"message size",
+			map[string]interface{}{
+				"kafka_message_max_bytes":       2097152,
+				"kafka_replica_fetch_max_bytes": 2097152,
+			},
+			nil,
+			map[string]interface{}{
+				"kafka": map[string]interface{}{
+					"message_max_bytes":       2097152,
+					"replica_fetch_max_bytes": 2097152,
+				},
+			},
+		},
+		{
+			"merged with the nested settings",
+			map[string]interface{}{"kafka_num_partitions": 6},
+			map[string]interface{}{
+				"kafka":           map[string]interface{}{"auto_create_topics_enable": true},
+				"schema_registry": true,
+			},
+			map[string]interface{}{
+				"kafka": map[string]interface{}{
+					"auto_create_topics_enable": true,
+					"num_partitions":            6,
+				},
+				"schema_registry": true,
+			},
+		},
+		{
+			"unset",
+			map[string]interface{}{},
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, aivenKafkaSchema(), tt.raw)
+			if got := expandKafkaBrokerSettings(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandKafkaBrokerSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expandKafkaBrokerSettingsUpdate(t *testing.T) {
+	state := map[string]string{
+		"project":                     "test-project",
+		"service_name":                "test-service",
+		"kafka_num_partitions":        "6",
+		"kafka_user_config.#":         "1",
+		"kafka_user_config.0.kafka.#": "1",
+		"kafka_user_config.0.kafka.0.num_partitions": "6",
+	}
+
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			// the shorthand keeps the value read back, which must not override the nested key
+			"nested key changed",
+			map[string]interface{}{
+				"project":      "test-project",
+				"service_name": "test-service",
+				"kafka_user_config": []interface{}{map[string]interface{}{
+					"kafka": []interface{}{map[string]interface{}{"num_partitions": "12"}},
+				}},
+			},
+			map[string]interface{}{"kafka": map[string]interface{}{"num_partitions": 12}},
+			map[string]interface{}{"kafka": map[string]interface{}{"num_partitions": 12}},
+		},
+		{
+			"shorthand changed",
+			map[string]interface{}{
+				"project":              "test-project",
+				"service_name":         "test-service",
+				"kafka_num_partitions": 12,
+			},
+			nil,
+			map[string]interface{}{"kafka": map[string]interface{}{"num_partitions": 12}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testResourceDataUpdate(t, resourceKafka(), state, tt.raw)
+			if got := expandKafkaBrokerSettings(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandKafkaBrokerSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flattenKafkaBrokerSettings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenKafkaSchema(), map[string]interface{}{})
+	err := flattenKafkaBrokerSettings(d, map[string]interface{}{
+		"kafka": map[string]interface{}{
+			"message_max_bytes": float64(2097152),
+			"num_partitions":    float64(6),
+		},
+	})
+	if err != nil {
+		t.Fatalf("flattenKafkaBrokerSettings() error = %v", err)
+	}
+	if got := d.Get("kafka_message_max_bytes"); got != 2097152 {
+		t.Errorf("kafka_message_max_bytes = %v, want 2097152", got)
+	}
+	if got := d.Get("kafka_num_partitions"); got != 6 {
+		t.Errorf("kafka_num_partitions = %v, want 6", got)
+	}
+	if got := d.Get("kafka_replica_fetch_max_bytes"); got != 0 {
+		t.Errorf("kafka_replica_fetch_max_bytes = %v, want 0", got)
+	}
+}
+
+func Test_kafkaBrokerSettingsValidation(t *testing.T) {
+	tests := []struct {
+		field   string
+		value   int
+		wantErr bool
+	}{
+		{"kafka_message_max_bytes", 1048588, false},
+		{"kafka_message_max_bytes", 100001201, true},
+		{"kafka_replica_fetch_max_bytes", 1048576, false},
+		{"kafka_replica_fetch_max_bytes", 1024, true},
+		{"kafka_num_partitions", 1000, false},
+		{"kafka_num_partitions", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s=%d", tt.field, tt.value), func(t *testing.T) {
+			_, errs := kafkaBrokerSettingsSchema()[tt.field].ValidateFunc(tt.value, tt.field)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("%s validation errors = %v, wantErr %v", tt.field, errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccAiven_kafkaBrokerSettings(t *testing.T) {
+	resourceName := "aiven_kafka.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaBrokerSettingsResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "kafka_message_max_bytes", "2097152"),
+					resource.TestCheckResourceAttr(resourceName, "kafka_replica_fetch_max_bytes", "2097152"),
+					resource.TestCheckResourceAttr(resourceName, "kafka_num_partitions", "6"),
+					resource.TestCheckResourceAttr(resourceName, "kafka_user_config.0.kafka.0.message_max_bytes", "2097152"),
+					resource.TestCheckResourceAttr(resourceName, "kafka_user_config.0.kafka.0.replica_fetch_max_bytes", "2097152"),
+					resource.TestCheckResourceAttr(resourceName, "kafka_user_config.0.kafka.0.num_partitions", "6"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaBrokerSettingsResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+			kafka_message_max_bytes = 2097152
+			kafka_replica_fetch_max_bytes = 2097152
+			kafka_num_partitions = 6
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
+
 func testAccKafkaResource(name string) string {
 	return fmt.Sprintf(`
 		data "aiven_project" "foo" {
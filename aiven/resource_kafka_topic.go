@@ -288,7 +288,7 @@ func resourceKafkaTopicCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	w := &KafkaTopicCreateWaiter{
-		Client:        m.(*aiven.Client),
+		Client:        m.(*providerMeta).client,
 		Project:       project,
 		ServiceName:   serviceName,
 		CreateRequest: createRequest,
@@ -445,7 +445,7 @@ func getTopic(ctx context.Context, d *schema.ResourceData, m interface{}, ignore
 	project, serviceName, topicName := splitResourceID3(d.Id())
 
 	w := &KafkaTopicAvailabilityWaiter{
-		Client:      m.(*aiven.Client),
+		Client:      m.(*providerMeta).client,
 		Project:     project,
 		ServiceName: serviceName,
 		TopicName:   topicName,
@@ -461,8 +461,33 @@ func getTopic(ctx context.Context, d *schema.ResourceData, m interface{}, ignore
 	return topic.(aiven.KafkaTopic), nil
 }
 
+// getKafkaTopics gets the details of the topics of a service, e.g. their partitions, all the
+// topics of the service when no names are given
+func getKafkaTopics(client *aiven.Client, project, serviceName string, names []string) ([]*aiven.KafkaTopic, error) {
+	if len(names) == 0 {
+		list, err := client.KafkaTopics.List(project, serviceName)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list topics of %s/%s: %s", project, serviceName, err)
+		}
+		for _, t := range list {
+			names = append(names, t.TopicName)
+		}
+	}
+
+	var topics []*aiven.KafkaTopic
+	for _, name := range names {
+		topic, err := client.KafkaTopics.Get(project, serviceName, name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get topic %s of %s/%s: %s", name, project, serviceName, err)
+		}
+		topics = append(topics, topic)
+	}
+
+	return topics, nil
+}
+
 func resourceKafkaTopicUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	partitions := d.Get("partitions").(int)
 	projectName, serviceName, topicName := splitResourceID3(d.Id())
@@ -488,7 +513,7 @@ func resourceKafkaTopicUpdate(_ context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceKafkaTopicDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, topicName := splitResourceID3(d.Id())
 
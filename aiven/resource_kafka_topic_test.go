@@ -5,11 +5,13 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -122,6 +124,94 @@ func TestAccAivenKafkaTopic_basic(t *testing.T) {
 	})
 }
 
+func TestAccAivenKafkaTopic_tags(t *testing.T) {
+	resourceName := "aiven_kafka_topic.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenKafkaTopicResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaTopicTagsResource(rName, `
+					tag {
+						key = "owner"
+						value = "data-platform"
+					}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "tag.*", map[string]string{
+						"key":   "owner",
+						"value": "data-platform",
+					}),
+				),
+			},
+			// a tag is added
+			{
+				Config: testAccKafkaTopicTagsResource(rName, `
+					tag {
+						key = "owner"
+						value = "data-platform"
+					}
+
+					tag {
+						key = "classification"
+						value = "pii"
+					}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "tag.*", map[string]string{
+						"key":   "classification",
+						"value": "pii",
+					}),
+				),
+			},
+			// a tag is modified and the other one removed
+			{
+				Config: testAccKafkaTopicTagsResource(rName, `
+					tag {
+						key = "owner"
+						value = "billing"
+					}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "tag.*", map[string]string{
+						"key":   "owner",
+						"value": "billing",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaTopicTagsResource(name, tags string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+		}
+
+		resource "aiven_kafka_topic" "foo" {
+			project = data.aiven_project.foo.project
+			service_name = aiven_kafka.bar.service_name
+			topic_name = "test-acc-topic-%s"
+			partitions = 3
+			replication = 2
+			%s
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, tags)
+}
+
 func TestAccAivenKafkaTopic_450topics(t *testing.T) {
 	if os.Getenv("AIVEN_ACC_LONG") == "" {
 		t.Skip("Acceptance tests skipped unless env AIVEN_ACC_LONG set")
@@ -331,7 +421,7 @@ func testAccCheckAivenKafkaTopicAttributes(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckAivenKafkaTopicResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each kafka topic is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -392,3 +482,29 @@ func Test_partitions(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateKafkaTopicTag(t *testing.T) {
+	tag := aivenKafkaTopicSchema["tag"].Elem.(*schema.Resource).Schema
+
+	tests := []struct {
+		name     string
+		validate schema.SchemaValidateFunc
+		value    string
+		wantErr  bool
+	}{
+		{"valid key", tag["key"].ValidateFunc, "team", false},
+		{"empty key", tag["key"].ValidateFunc, "", true},
+		{"over-long key", tag["key"].ValidateFunc, strings.Repeat("k", 65), true},
+		{"valid value", tag["value"].ValidateFunc, "data platform", false},
+		{"empty value", tag["value"].ValidateFunc, "", false},
+		{"over-long value", tag["value"].ValidateFunc, strings.Repeat("v", 257), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := tt.validate(tt.value, "tag")
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validate() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
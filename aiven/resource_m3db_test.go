@@ -104,3 +104,79 @@ func testAccM3DBResource(name string) string {
 		}
 		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, name)
 }
+
+func TestAccAiven_m3dbMappingRule(t *testing.T) {
+	resourceName := "aiven_m3db.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccM3DBMappingRuleResource(rName, "Max"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+					resource.TestCheckResourceAttr(resourceName, "m3db_user_config.0.rules.0.mapping.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "m3db_user_config.0.rules.0.mapping.0.filter", "__name__:disk_*"),
+					resource.TestCheckResourceAttr(resourceName, "m3db_user_config.0.rules.0.mapping.0.aggregations.0", "Max"),
+					resource.TestCheckResourceAttr(resourceName, "m3db_user_config.0.rules.0.mapping.0.namespaces.0", "aggregated_*"),
+				),
+			},
+			{
+				// the rules are part of the user config and updated in place
+				Config: testAccM3DBMappingRuleResource(rName, "Mean"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "m3db_user_config.0.rules.0.mapping.0.aggregations.0", "Mean"),
+				),
+			},
+		},
+	})
+}
+
+func testAccM3DBMappingRuleResource(name, aggregation string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_m3db" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-8"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+
+			m3db_user_config {
+				namespaces {
+					name = "default"
+					type = "unaggregated"
+				}
+
+				namespaces {
+					name = "aggregated_1h"
+					type = "aggregated"
+					resolution = "1h"
+
+					options {
+						retention_options {
+							retention_period_duration = "720h"
+						}
+					}
+				}
+
+				rules {
+					mapping {
+						name = "disk metrics"
+						filter = "__name__:disk_*"
+						aggregations = ["%s"]
+						namespaces = ["aggregated_*"]
+					}
+				}
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, aggregation)
+}
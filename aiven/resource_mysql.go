@@ -13,10 +13,18 @@ func aivenMySQLSchema() map[string]*schema.Schema {
 		Computed:    true,
 		Description: "MySQL specific server provided values",
 		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{},
+			Schema: map[string]*schema.Schema{
+				"replica_uri": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "URI of the read replica of the service, the MySQL service with a `read_replica` integration from it",
+					Sensitive:   true,
+				},
+			},
 		},
 	}
 	schemaMySQL[ServiceTypeMySQL+"_user_config"] = generateServiceUserConfiguration(ServiceTypeMySQL)
+	schemaMySQL["wait_for_read_replica"] = serviceWaitForReadReplicaSchema()
 
 	return schemaMySQL
 }
@@ -30,9 +38,11 @@ func resourceMySQL() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeMySQL),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: aivenMySQLSchema(),
@@ -2,11 +2,16 @@ package aiven
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
 
+	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccAiven_mysql(t *testing.T) {
@@ -72,3 +77,165 @@ func testAccMysqlResource(name string) string {
 		}
 		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
 }
+
+func Test_mysqlReadReplica(t *testing.T) {
+	source, replica, other := "test-mysql", "test-mysql-replica", "test-mysql-source"
+
+	tests := []struct {
+		name         string
+		integrations []*aiven.ServiceIntegration
+		want         string
+	}{
+		{
+			"read replica",
+			[]*aiven.ServiceIntegration{
+				{IntegrationType: "metrics", SourceService: &source, DestinationService: &other},
+				{IntegrationType: "read_replica", SourceService: &source, DestinationService: &replica},
+			},
+			replica,
+		},
+		{
+			"replica of another service",
+			[]*aiven.ServiceIntegration{
+				{IntegrationType: "read_replica", SourceService: &other, DestinationService: &source},
+			},
+			"",
+		},
+		{
+			"no integrations",
+			nil,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &aiven.Service{Name: source, Type: ServiceTypeMySQL, Integrations: tt.integrations}
+			if got := mysqlReadReplica(s); got != tt.want {
+				t.Errorf("mysqlReadReplica() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setMySQLReplicaURI(t *testing.T) {
+	source, replica := "test-mysql", "test-mysql-replica"
+	readReplica := []*aiven.ServiceIntegration{
+		{IntegrationType: "read_replica", SourceService: &source, DestinationService: &replica},
+	}
+
+	tests := []struct {
+		name         string
+		integrations []*aiven.ServiceIntegration
+		status       int
+		wantRequests int
+		wantErr      bool
+	}{
+		{
+			"no read replica",
+			nil,
+			http.StatusOK,
+			0,
+			false,
+		},
+		{
+			"deleted read replica",
+			readReplica,
+			http.StatusNotFound,
+			1,
+			false,
+		},
+		{
+			"API error",
+			readReplica,
+			http.StatusInternalServerError,
+			1,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"message":"error"}`))
+			}))
+			defer srv.Close()
+
+			target, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client, err := aiven.NewTokenClient("token", "test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.Client.Transport = testRedirectTransport{target: target}
+
+			d := schema.TestResourceDataRaw(t, aivenMySQLSchema(), map[string]interface{}{})
+			s := &aiven.Service{Name: source, Type: ServiceTypeMySQL, Integrations: tt.integrations}
+			err = setMySQLReplicaURI(d, client, "test-project", s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setMySQLReplicaURI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("setMySQLReplicaURI() sent %d requests, want %d", requests, tt.wantRequests)
+			}
+			if got := d.Get("mysql.0.replica_uri"); !tt.wantErr && got != "" {
+				t.Errorf("replica_uri = %v, want it empty", got)
+			}
+		})
+	}
+}
+
+func TestAccAiven_mysql_readReplica(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMysqlReadReplicaResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_mysql.replica", "state", "RUNNING"),
+				),
+			},
+			{
+				// the replica is created after its source, which only reports it once refreshed
+				Config: testAccMysqlReadReplicaResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("aiven_mysql.bar", "mysql.0.replica_uri", "aiven_mysql.replica", "service_uri"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMysqlReadReplicaResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_mysql" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_mysql" "replica" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-replica-%s"
+
+			service_integrations {
+				integration_type = "read_replica"
+				source_service_name = aiven_mysql.bar.service_name
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
@@ -6,8 +6,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func opensearchSchema() map[string]*schema.Schema {
@@ -28,6 +28,7 @@ func opensearchSchema() map[string]*schema.Schema {
 		},
 	}
 	s[ServiceTypeOpensearch+"_user_config"] = generateServiceUserConfiguration(ServiceTypeOpensearch)
+	s["opensearch_index_template"] = opensearchIndexTemplateSchema()
 
 	return s
 }
@@ -42,9 +43,11 @@ func resourceOpensearch() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceElasticsearchState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeOpensearch),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: opensearchSchema(),
@@ -52,7 +55,7 @@ func resourceOpensearch() *schema.Resource {
 }
 
 func resourceElasticsearchState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
@@ -75,3 +78,93 @@ func resourceElasticsearchState(ctx context.Context, d *schema.ResourceData, m i
 
 	return resourceServiceState(ctx, d, m)
 }
+
+// opensearchIndexTemplateDefaults are the Opensearch defaults of the template settings, a
+// configured template sets all of them
+var opensearchIndexTemplateDefaults = map[string]int{
+	"mapping_nested_objects_limit": 10000,
+	"number_of_replicas":           1,
+	"number_of_shards":             1,
+}
+
+func opensearchIndexTemplateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		Computed:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"opensearch_user_config.0.index_template"},
+		Description:   "Template settings of all the new indexes of the service. Shorthand for `opensearch_user_config.index_template`, the settings left out of the block are reset to their default.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"mapping_nested_objects_limit": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      opensearchIndexTemplateDefaults["mapping_nested_objects_limit"],
+					ValidateFunc: validation.IntBetween(0, 100000),
+					Description:  complex("Maximum number of nested JSON objects a single document can contain across all its nested types.").defaultValue(opensearchIndexTemplateDefaults["mapping_nested_objects_limit"]).build(),
+				},
+				"number_of_replicas": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      opensearchIndexTemplateDefaults["number_of_replicas"],
+					ValidateFunc: validation.IntBetween(0, 29),
+					Description:  complex("Number of replicas of each primary shard.").defaultValue(opensearchIndexTemplateDefaults["number_of_replicas"]).build(),
+				},
+				"number_of_shards": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      opensearchIndexTemplateDefaults["number_of_shards"],
+					ValidateFunc: validation.IntBetween(1, 1024),
+					Description:  complex("Number of primary shards of an index.").defaultValue(opensearchIndexTemplateDefaults["number_of_shards"]).build(),
+				},
+			},
+		},
+	}
+}
+
+// expandOpensearchIndexTemplate adds the opensearch_index_template block, when it is set, to
+// the user config sent to the API, see userConfigShorthand
+func expandOpensearchIndexTemplate(d *schema.ResourceData, userConfig map[string]interface{}) map[string]interface{} {
+	v, ok := userConfigShorthand(d, "opensearch_index_template")
+	if !ok {
+		return userConfig
+	}
+
+	templates := v.([]interface{})
+	if len(templates) == 0 || templates[0] == nil {
+		return userConfig
+	}
+
+	if userConfig == nil {
+		userConfig = make(map[string]interface{})
+	}
+
+	template := make(map[string]interface{})
+	for k, v := range templates[0].(map[string]interface{}) {
+		template[k] = v
+	}
+	userConfig["index_template"] = template
+
+	return userConfig
+}
+
+// flattenOpensearchIndexTemplate converts the index template of the service user config to
+// the opensearch_index_template block, the settings the service does not report have their
+// default value
+func flattenOpensearchIndexTemplate(userConfig map[string]interface{}) []map[string]interface{} {
+	template, ok := userConfig["index_template"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	block := make(map[string]interface{})
+	for k, v := range opensearchIndexTemplateDefaults {
+		block[k] = v
+		if f, ok := template[k].(float64); ok {
+			block[k] = int(f)
+		}
+	}
+
+	return []map[string]interface{}{block}
+}
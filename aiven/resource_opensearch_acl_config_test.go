@@ -64,7 +64,7 @@ func testAccOpensearchACLConfigResource(name string) string {
 }
 
 func testAccCheckAivenOpensearchACLConfigResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each OS ACL Config is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -0,0 +1,351 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenOpensearchReindexSchema = map[string]*schema.Schema{
+	"project":      commonSchemaProjectReference,
+	"service_name": commonSchemaServiceNameReference,
+	"source_index": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("Index or index pattern to copy the documents from.").forceNew().build(),
+	},
+	"destination_index": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("Index to copy the documents to, e.g. an index created with the new mappings.").forceNew().build(),
+	},
+	"conflicts": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Default:      "abort",
+		ValidateFunc: validation.StringInSlice([]string{"abort", "proceed"}, false),
+		Description: complex("What to do when a document conflicts with a newer version in the destination index, `abort` fails the reindex and `proceed` counts the conflict and continues.").
+			defaultValue("abort").possibleValues("abort", "proceed").forceNew().build(),
+	},
+	"wait_for_completion": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: complex("Waits for the reindex to complete, otherwise the reindex runs in the background and its progress is refreshed by Terraform.").defaultValue(true).build(),
+	},
+	"task_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the OpenSearch task running the reindex",
+	},
+	"completed": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Tells if the reindex is complete",
+	},
+	"total": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Number of documents to reindex",
+	},
+	"created": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Number of documents created in the destination index",
+	},
+	"updated": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Number of documents updated in the destination index",
+	},
+	"version_conflicts": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Number of documents that conflicted with a newer version in the destination index",
+	},
+}
+
+func resourceOpensearchReindex() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Opensearch Reindex resource copies the documents of an index of an Aiven Opensearch service to another index, e.g. after a mapping change, and tracks the task doing it. Deleting the resource cancels a reindex that is still running, the destination index is kept. The reindex is run through the OpenSearch API of the service with its admin credentials, so the service must be reachable from where Terraform runs.",
+		CreateContext: resourceOpensearchReindexCreate,
+		ReadContext:   resourceOpensearchReindexRead,
+		UpdateContext: resourceOpensearchReindexUpdate,
+		DeleteContext: resourceOpensearchReindexDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenOpensearchReindexSchema,
+	}
+}
+
+func resourceOpensearchReindexCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	source := d.Get("source_index").(string)
+	destination := d.Get("destination_index").(string)
+
+	api, err := opensearchServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	taskID, err := api.startReindex(ctx, source, destination, d.Get("conflicts").(string))
+	if err != nil {
+		return diag.Errorf("cannot reindex %s to %s on %s/%s: %s", source, destination, project, serviceName, err)
+	}
+
+	// the reindex runs from now on, a failed one is tainted and started again on the next apply
+	d.SetId(buildResourceID(project, serviceName, taskID))
+
+	if d.Get("wait_for_completion").(bool) {
+		if _, err := api.waitForReindex(ctx, taskID, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceOpensearchReindexRead(ctx, d, m)
+}
+
+func resourceOpensearchReindexRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, taskID := splitResourceID3(d.Id())
+
+	api, err := opensearchServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	task, err := api.getReindexTask(ctx, taskID)
+	if err != nil {
+		// the result of a task is not kept forever, the reindex is not run again for it
+		if aiven.IsNotFound(err) {
+			log.Printf("[WARN] reindex task %s of %s/%s is gone, keeping its last known state", taskID, project, serviceName)
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("task_id", taskID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("completed", task.Completed); err != nil {
+		return diag.FromErr(err)
+	}
+
+	status := task.status()
+	if err := d.Set("total", status.Total); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("created", status.Created); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("updated", status.Updated); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version_conflicts", status.VersionConflicts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchReindexUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, taskID := splitResourceID3(d.Id())
+
+	// only wait_for_completion can change, a reindex still running is waited for once it is set
+	if d.Get("wait_for_completion").(bool) && !d.Get("completed").(bool) {
+		api, err := opensearchServiceAPI(client, project, serviceName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if _, err := api.waitForReindex(ctx, taskID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceOpensearchReindexRead(ctx, d, m)
+}
+
+func resourceOpensearchReindexDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, taskID := splitResourceID3(d.Id())
+
+	if d.Get("completed").(bool) {
+		return nil
+	}
+
+	api, err := opensearchServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	_, err = api.do(ctx, http.MethodPost, opensearchTaskPath(taskID)+"/_cancel", "", nil)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot cancel reindex task %s: %s", taskID, err)
+	}
+
+	return nil
+}
+
+type opensearchReindexTask struct {
+	Completed bool `json:"completed"`
+	Task      struct {
+		Status opensearchReindexStatus `json:"status"`
+	} `json:"task"`
+	Response *opensearchReindexStatus `json:"response"`
+	Error    *opensearchTaskError     `json:"error"`
+}
+
+type opensearchReindexStatus struct {
+	Total            int                        `json:"total"`
+	Created          int                        `json:"created"`
+	Updated          int                        `json:"updated"`
+	VersionConflicts int                        `json:"version_conflicts"`
+	Failures         []opensearchReindexFailure `json:"failures"`
+}
+
+type opensearchReindexFailure struct {
+	Index string              `json:"index"`
+	ID    string              `json:"id"`
+	Cause opensearchTaskError `json:"cause"`
+}
+
+type opensearchTaskError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// status returns the final status of a completed task and the progress of a running one
+func (t *opensearchReindexTask) status() opensearchReindexStatus {
+	if t.Completed && t.Response != nil {
+		return *t.Response
+	}
+
+	return t.Task.Status
+}
+
+func opensearchTaskPath(taskID string) string {
+	return "/_tasks/" + url.PathEscape(taskID)
+}
+
+// startReindex starts a reindex in the background and returns the ID of its task
+func (o *serviceAPI) startReindex(ctx context.Context, source, destination, conflicts string) (string, error) {
+	b, err := json.Marshal(map[string]interface{}{
+		"conflicts": conflicts,
+		"source":    map[string]string{"index": source},
+		"dest":      map[string]string{"index": destination},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	b, err = o.do(ctx, http.MethodPost, "/_reindex?wait_for_completion=false", "application/json", bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+
+	var r struct {
+		Task string `json:"task"`
+	}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return "", fmt.Errorf("cannot parse reindex task: %s", err)
+	}
+
+	return r.Task, nil
+}
+
+func (o *serviceAPI) getReindexTask(ctx context.Context, taskID string) (*opensearchReindexTask, error) {
+	b, err := o.do(ctx, http.MethodGet, opensearchTaskPath(taskID), "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var task opensearchReindexTask
+	if err := json.Unmarshal(b, &task); err != nil {
+		return nil, fmt.Errorf("cannot parse reindex task: %s", err)
+	}
+
+	return &task, nil
+}
+
+// waitForReindex polls a reindex task until it is complete, a reindex that failed or
+// stored only some of the documents is reported with its failures
+func (o *serviceAPI) waitForReindex(ctx context.Context, taskID string, timeout time.Duration) (*opensearchReindexTask, error) {
+	conf := &resource.StateChangeConf{
+		Pending: []string{"RUNNING"},
+		Target:  []string{"COMPLETED"},
+		Refresh: func() (interface{}, string, error) {
+			task, err := o.getReindexTask(ctx, taskID)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if !task.Completed {
+				return task, "RUNNING", nil
+			}
+			if err := opensearchReindexError(taskID, task); err != nil {
+				return nil, "", err
+			}
+			return task, "COMPLETED", nil
+		},
+		Timeout:    timeout,
+		MinTimeout: time.Second,
+	}
+
+	task, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return task.(*opensearchReindexTask), nil
+}
+
+func opensearchReindexError(taskID string, task *opensearchReindexTask) error {
+	if task.Error != nil {
+		return fmt.Errorf("reindex task %s failed: %s: %s", taskID, task.Error.Type, task.Error.Reason)
+	}
+
+	status := task.status()
+	if len(status.Failures) == 0 {
+		return nil
+	}
+
+	var failures []string
+	for _, f := range status.Failures {
+		failures = append(failures, fmt.Sprintf("%s/%s: %s", f.Index, f.ID, f.Cause.Reason))
+	}
+
+	return fmt.Errorf("reindex task %s failed for %d documents: %s", taskID, len(failures), strings.Join(failures, "; "))
+}
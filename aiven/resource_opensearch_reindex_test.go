@@ -0,0 +1,154 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_opensearchReindex(t *testing.T) {
+	running := `{"completed":false,"task":{"status":{"total":10,"created":4,"updated":0,"version_conflicts":0}}}`
+
+	tests := []struct {
+		name        string
+		tasks       []string
+		wantCreated int
+		wantErr     string
+	}{
+		{
+			"success",
+			[]string{
+				running,
+				`{"completed":true,"task":{"status":{"total":10,"created":10}},` +
+					`"response":{"total":10,"created":9,"updated":0,"version_conflicts":1,"failures":[]}}`,
+			},
+			9,
+			"",
+		},
+		{
+			"conflicts",
+			[]string{
+				`{"completed":true,"response":{"total":10,"created":9,"version_conflicts":1,` +
+					`"failures":[{"index":"logs-v2","id":"7","cause":{"type":"version_conflict_engine_exception","reason":"version conflict"}}]}}`,
+			},
+			0,
+			"reindex task node-1:42 failed for 1 documents: logs-v2/7: version conflict",
+		},
+		{
+			"failed",
+			[]string{
+				running,
+				`{"completed":true,"error":{"type":"index_not_found_exception","reason":"no such index [logs]"}}`,
+			},
+			0,
+			"reindex task node-1:42 failed: index_not_found_exception: no such index [logs]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/_reindex":
+					if r.URL.Query().Get("wait_for_completion") != "false" {
+						t.Errorf("unexpected reindex query %s", r.URL.RawQuery)
+					}
+					b, _ := ioutil.ReadAll(r.Body)
+					if string(b) != `{"conflicts":"abort","dest":{"index":"logs-v2"},"source":{"index":"logs"}}` {
+						t.Errorf("unexpected reindex request %s", b)
+					}
+					_, _ = w.Write([]byte(`{"task":"node-1:42"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/_tasks/node-1:42":
+					task := tt.tasks[polls]
+					if polls < len(tt.tasks)-1 {
+						polls++
+					}
+					_, _ = w.Write([]byte(task))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer srv.Close()
+
+			api, err := newServiceAPI(srv.URL, "avnadmin", "secret", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			taskID, err := api.startReindex(ctx, "logs", "logs-v2", "abort")
+			if err != nil {
+				t.Fatalf("startReindex() error = %v", err)
+			}
+			if taskID != "node-1:42" {
+				t.Errorf("startReindex() = %s, want node-1:42", taskID)
+			}
+
+			task, err := api.waitForReindex(ctx, taskID, time.Minute)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("waitForReindex() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("waitForReindex() error = %v", err)
+			}
+			if got := task.status().Created; got != tt.wantCreated {
+				t.Errorf("waitForReindex() created = %d, want %d", got, tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestAccAivenOpensearchReindex_basic(t *testing.T) {
+	// the documents to reindex are not managed by the provider, an existing index is used
+	if os.Getenv("AIVEN_OPENSEARCH_REINDEX_SERVICE") == "" ||
+		os.Getenv("AIVEN_OPENSEARCH_REINDEX_SOURCE_INDEX") == "" {
+		t.Skip("AIVEN_OPENSEARCH_REINDEX_SERVICE and AIVEN_OPENSEARCH_REINDEX_SOURCE_INDEX env variables are required to run this test")
+	}
+
+	resourceName := "aiven_opensearch_reindex.foo"
+	destinationIndex := fmt.Sprintf("test-acc-reindex-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchReindexResource(destinationIndex),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", os.Getenv("AIVEN_OPENSEARCH_REINDEX_SERVICE")),
+					resource.TestCheckResourceAttr(resourceName, "destination_index", destinationIndex),
+					resource.TestCheckResourceAttr(resourceName, "completed", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "task_id"),
+					resource.TestCheckResourceAttr(resourceName, "version_conflicts", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOpensearchReindexResource(destinationIndex string) string {
+	return fmt.Sprintf(`
+    resource "aiven_opensearch_reindex" "foo" {
+      project = "%s"
+      service_name = "%s"
+      source_index = "%s"
+      destination_index = "%s"
+    }`,
+		os.Getenv("AIVEN_PROJECT_NAME"),
+		os.Getenv("AIVEN_OPENSEARCH_REINDEX_SERVICE"),
+		os.Getenv("AIVEN_OPENSEARCH_REINDEX_SOURCE_INDEX"),
+		destinationIndex)
+}
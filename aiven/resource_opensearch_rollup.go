@@ -0,0 +1,451 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var opensearchRollupAggregations = []string{"avg", "max", "min", "sum", "value_count"}
+
+var aivenOpensearchRollupSchema = map[string]*schema.Schema{
+	"project":      commonSchemaProjectReference,
+	"service_name": commonSchemaServiceNameReference,
+	"rollup_id": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  complex("Name of the rollup job.").forceNew().build(),
+	},
+	"description": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: complex("Description of the rollup job.").forceNew().build(),
+	},
+	"source_index": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("Index or index pattern to roll up.").forceNew().build(),
+	},
+	"target_index": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("Index the rolled up data is written to.").forceNew().build(),
+	},
+	"schedule_interval": {
+		Type:         schema.TypeInt,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  complex("Interval between the runs of the rollup job, in `schedule_unit` units.").forceNew().build(),
+	},
+	"schedule_unit": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Default:      "Minutes",
+		ValidateFunc: validation.StringInSlice([]string{"Minutes", "Hours", "Days"}, false),
+		Description:  complex("Unit of `schedule_interval`.").forceNew().defaultValue("Minutes").possibleValues("Minutes", "Hours", "Days").build(),
+	},
+	"page_size": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ForceNew:     true,
+		Default:      1000,
+		ValidateFunc: validation.IntBetween(1, 10000),
+		Description:  complex("Number of buckets processed by each search of the rollup job.").forceNew().defaultValue(1000).build(),
+	},
+	"continuous": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     false,
+		Description: complex("Keep rolling up new data after the existing data is rolled up.").forceNew().defaultValue(false).build(),
+	},
+	"date_histogram": {
+		Type:        schema.TypeList,
+		Required:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Description: complex("Time dimension of the rollup.").forceNew().build(),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"source_field": {
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+					Description: complex("Timestamp field of the source index.").forceNew().build(),
+				},
+				"fixed_interval": {
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+					Description: complex("Size of the time buckets, e.g. `1h`.").forceNew().build(),
+				},
+				"timezone": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    true,
+					Default:     "UTC",
+					Description: complex("Timezone of the time buckets.").forceNew().defaultValue("UTC").build(),
+				},
+			},
+		},
+	},
+	"terms": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		Description: complex("Fields of the source index used as terms dimensions of the rollup.").forceNew().build(),
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+	"metric": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		Description: complex("Aggregations computed for a numeric field of the source index.").forceNew().build(),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"source_field": {
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+					Description: complex("Numeric field of the source index.").forceNew().build(),
+				},
+				"aggregations": {
+					Type:        schema.TypeList,
+					Required:    true,
+					ForceNew:    true,
+					MinItems:    1,
+					Description: complex("Aggregations of the field.").forceNew().possibleValues(stringSliceToInterfaceSlice(opensearchRollupAggregations)...).build(),
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(opensearchRollupAggregations, false),
+					},
+				},
+			},
+		},
+	},
+	"enabled": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: complex("Run the rollup job on its schedule. Disabling the job stops it, enabling it starts it again.").defaultValue(true).build(),
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Status of the rollup job, e.g. `init`, `started`, `stopped`, `finished` or `failed`",
+	},
+}
+
+func resourceOpensearchRollup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Opensearch Rollup resource allows the creation and management of OpenSearch index rollup jobs on an Aiven Opensearch service. The rollup jobs are managed through the OpenSearch API of the service with its admin credentials, so the service must be reachable from where Terraform runs.",
+		CreateContext: resourceOpensearchRollupCreate,
+		ReadContext:   resourceOpensearchRollupRead,
+		UpdateContext: resourceOpensearchRollupUpdate,
+		DeleteContext: resourceOpensearchRollupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenOpensearchRollupSchema,
+	}
+}
+
+func resourceOpensearchRollupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	rollupID := d.Get("rollup_id").(string)
+
+	api, err := opensearchServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := api.putRollup(ctx, rollupID, expandOpensearchRollup(d)); err != nil {
+		return diag.Errorf("cannot create rollup job %s on %s/%s: %s", rollupID, project, serviceName, err)
+	}
+
+	d.SetId(buildResourceID(project, serviceName, rollupID))
+
+	return resourceOpensearchRollupRead(ctx, d, m)
+}
+
+func resourceOpensearchRollupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, rollupID := splitResourceID3(d.Id())
+
+	api, err := opensearchServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	rollup, err := api.getRollup(ctx, rollupID)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	state, err := api.rollupState(ctx, rollupID)
+	if err != nil {
+		return diag.Errorf("cannot get the state of rollup job %s: %s", rollupID, err)
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("rollup_id", rollupID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", state); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := flattenOpensearchRollup(d, rollup); err != nil {
+		return diag.Errorf("cannot set rollup job %s: %s", rollupID, err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchRollupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, rollupID := splitResourceID3(d.Id())
+
+	// all the other settings of a rollup job recreate it
+	if d.HasChange("enabled") {
+		api, err := opensearchServiceAPI(client, project, serviceName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := api.setRollupEnabled(ctx, rollupID, d.Get("enabled").(bool)); err != nil {
+			return diag.Errorf("cannot change rollup job %s: %s", rollupID, err)
+		}
+	}
+
+	return resourceOpensearchRollupRead(ctx, d, m)
+}
+
+func resourceOpensearchRollupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, rollupID := splitResourceID3(d.Id())
+
+	api, err := opensearchServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	_, err = api.do(ctx, http.MethodDelete, "/_plugins/_rollup/jobs/"+url.PathEscape(rollupID), "", nil)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete rollup job %s: %s", rollupID, err)
+	}
+
+	return nil
+}
+
+type opensearchRollup struct {
+	Enabled     bool                                   `json:"enabled"`
+	Schedule    opensearchRollupSchedule               `json:"schedule"`
+	Description string                                 `json:"description"`
+	SourceIndex string                                 `json:"source_index"`
+	TargetIndex string                                 `json:"target_index"`
+	PageSize    int                                    `json:"page_size"`
+	Continuous  bool                                   `json:"continuous"`
+	Dimensions  []map[string]opensearchRollupDimension `json:"dimensions"`
+	Metrics     []opensearchRollupMetric               `json:"metrics"`
+}
+
+type opensearchRollupSchedule struct {
+	Interval struct {
+		Period    int    `json:"period"`
+		Unit      string `json:"unit"`
+		StartTime int64  `json:"start_time"`
+	} `json:"interval"`
+}
+
+type opensearchRollupDimension struct {
+	SourceField   string `json:"source_field"`
+	FixedInterval string `json:"fixed_interval,omitempty"`
+	Timezone      string `json:"timezone,omitempty"`
+}
+
+type opensearchRollupMetric struct {
+	SourceField string                         `json:"source_field"`
+	Metrics     []map[string]map[string]string `json:"metrics"`
+}
+
+func expandOpensearchRollup(d *schema.ResourceData) opensearchRollup {
+	r := opensearchRollup{
+		Enabled:     d.Get("enabled").(bool),
+		Description: d.Get("description").(string),
+		SourceIndex: d.Get("source_index").(string),
+		TargetIndex: d.Get("target_index").(string),
+		PageSize:    d.Get("page_size").(int),
+		Continuous:  d.Get("continuous").(bool),
+	}
+	r.Schedule.Interval.Period = d.Get("schedule_interval").(int)
+	r.Schedule.Interval.Unit = d.Get("schedule_unit").(string)
+	r.Schedule.Interval.StartTime = time.Now().UnixNano() / int64(time.Millisecond)
+
+	histogram := d.Get("date_histogram").([]interface{})[0].(map[string]interface{})
+	r.Dimensions = append(r.Dimensions, map[string]opensearchRollupDimension{
+		"date_histogram": {
+			SourceField:   histogram["source_field"].(string),
+			FixedInterval: histogram["fixed_interval"].(string),
+			Timezone:      histogram["timezone"].(string),
+		},
+	})
+
+	for _, field := range d.Get("terms").([]interface{}) {
+		r.Dimensions = append(r.Dimensions, map[string]opensearchRollupDimension{
+			"terms": {SourceField: field.(string)},
+		})
+	}
+
+	for _, v := range d.Get("metric").([]interface{}) {
+		metric := v.(map[string]interface{})
+
+		m := opensearchRollupMetric{SourceField: metric["source_field"].(string)}
+		for _, a := range metric["aggregations"].([]interface{}) {
+			m.Metrics = append(m.Metrics, map[string]map[string]string{a.(string): {}})
+		}
+		r.Metrics = append(r.Metrics, m)
+	}
+
+	return r
+}
+
+func flattenOpensearchRollup(d *schema.ResourceData, r *opensearchRollup) error {
+	var histogram, terms []interface{}
+	for _, dimension := range r.Dimensions {
+		if h, ok := dimension["date_histogram"]; ok {
+			histogram = append(histogram, map[string]interface{}{
+				"source_field":   h.SourceField,
+				"fixed_interval": h.FixedInterval,
+				"timezone":       h.Timezone,
+			})
+		}
+		if t, ok := dimension["terms"]; ok {
+			terms = append(terms, t.SourceField)
+		}
+	}
+
+	var metrics []interface{}
+	for _, m := range r.Metrics {
+		var aggregations []interface{}
+		for _, a := range m.Metrics {
+			for name := range a {
+				aggregations = append(aggregations, name)
+			}
+		}
+		metrics = append(metrics, map[string]interface{}{
+			"source_field": m.SourceField,
+			"aggregations": aggregations,
+		})
+	}
+
+	values := map[string]interface{}{
+		"enabled":           r.Enabled,
+		"description":       r.Description,
+		"source_index":      r.SourceIndex,
+		"target_index":      r.TargetIndex,
+		"page_size":         r.PageSize,
+		"continuous":        r.Continuous,
+		"schedule_interval": r.Schedule.Interval.Period,
+		"schedule_unit":     r.Schedule.Interval.Unit,
+		"date_histogram":    histogram,
+		"terms":             terms,
+		"metric":            metrics,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *serviceAPI) putRollup(ctx context.Context, rollupID string, r opensearchRollup) error {
+	b, err := json.Marshal(map[string]interface{}{"rollup": r})
+	if err != nil {
+		return err
+	}
+
+	_, err = o.do(ctx, http.MethodPut, "/_plugins/_rollup/jobs/"+url.PathEscape(rollupID), "application/json", bytes.NewReader(b))
+	return err
+}
+
+func (o *serviceAPI) getRollup(ctx context.Context, rollupID string) (*opensearchRollup, error) {
+	b, err := o.do(ctx, http.MethodGet, "/_plugins/_rollup/jobs/"+url.PathEscape(rollupID), "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Rollup opensearchRollup `json:"rollup"`
+	}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("cannot parse rollup job: %s", err)
+	}
+
+	return &r.Rollup, nil
+}
+
+// setRollupEnabled starts a disabled rollup job or stops an enabled one
+func (o *serviceAPI) setRollupEnabled(ctx context.Context, rollupID string, enabled bool) error {
+	action := "_stop"
+	if enabled {
+		action = "_start"
+	}
+
+	_, err := o.do(ctx, http.MethodPost, "/_plugins/_rollup/jobs/"+url.PathEscape(rollupID)+"/"+action, "", nil)
+	return err
+}
+
+// rollupState reads the status of a rollup job from its metadata, a job that has not
+// run yet has no metadata and is reported as `init`
+func (o *serviceAPI) rollupState(ctx context.Context, rollupID string) (string, error) {
+	b, err := o.do(ctx, http.MethodGet, "/_plugins/_rollup/jobs/"+url.PathEscape(rollupID)+"/_explain", "", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var r map[string]struct {
+		RollupMetadata *struct {
+			Status string `json:"status"`
+		} `json:"rollup_metadata"`
+	}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return "", fmt.Errorf("cannot parse rollup job explanation: %s", err)
+	}
+
+	if job, ok := r[rollupID]; ok && job.RollupMetadata != nil && job.RollupMetadata.Status != "" {
+		return job.RollupMetadata.Status, nil
+	}
+
+	return "init", nil
+}
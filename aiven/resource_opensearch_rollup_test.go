@@ -0,0 +1,180 @@
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_opensearchRollup(t *testing.T) {
+	var requests []string
+	var created map[string]opensearchRollup
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /_plugins/_rollup/jobs/metrics-hourly":
+			b, _ := ioutil.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &created); err != nil {
+				t.Errorf("cannot parse rollup job: %s", err)
+			}
+			_, _ = w.Write(b)
+		case "GET /_plugins/_rollup/jobs/metrics-hourly":
+			b, _ := json.Marshal(created)
+			_, _ = w.Write(b)
+		case "GET /_plugins/_rollup/jobs/metrics-hourly/_explain":
+			_, _ = w.Write([]byte(`{"metrics-hourly":{"metadata_id":"m1","rollup_metadata":{"status":"started"}}}`))
+		case "POST /_plugins/_rollup/jobs/metrics-hourly/_stop":
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	d := schema.TestResourceDataRaw(t, aivenOpensearchRollupSchema, map[string]interface{}{
+		"project":           "test-project",
+		"service_name":      "test-service",
+		"rollup_id":         "metrics-hourly",
+		"source_index":      "metrics-*",
+		"target_index":      "metrics-rollup",
+		"schedule_interval": 1,
+		"date_histogram": []interface{}{
+			map[string]interface{}{"source_field": "@timestamp", "fixed_interval": "1h"},
+		},
+		"terms": []interface{}{"host"},
+		"metric": []interface{}{
+			map[string]interface{}{"source_field": "cpu", "aggregations": []interface{}{"avg", "max"}},
+		},
+	})
+
+	api, err := newServiceAPI(srv.URL, "avnadmin", "secret", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := api.putRollup(ctx, "metrics-hourly", expandOpensearchRollup(d)); err != nil {
+		t.Fatalf("putRollup() error = %v", err)
+	}
+
+	got := created["rollup"]
+	if got.Schedule.Interval.Period != 1 || got.Schedule.Interval.Unit != "Minutes" || got.Schedule.Interval.StartTime == 0 {
+		t.Errorf("putRollup() schedule = %+v", got.Schedule)
+	}
+	wantDimensions := []map[string]opensearchRollupDimension{
+		{"date_histogram": {SourceField: "@timestamp", FixedInterval: "1h", Timezone: "UTC"}},
+		{"terms": {SourceField: "host"}},
+	}
+	if !reflect.DeepEqual(got.Dimensions, wantDimensions) {
+		t.Errorf("putRollup() dimensions = %v, want %v", got.Dimensions, wantDimensions)
+	}
+	wantMetrics := []opensearchRollupMetric{
+		{SourceField: "cpu", Metrics: []map[string]map[string]string{{"avg": {}}, {"max": {}}}},
+	}
+	if !reflect.DeepEqual(got.Metrics, wantMetrics) {
+		t.Errorf("putRollup() metrics = %v, want %v", got.Metrics, wantMetrics)
+	}
+
+	rollup, err := api.getRollup(ctx, "metrics-hourly")
+	if err != nil {
+		t.Fatalf("getRollup() error = %v", err)
+	}
+	read := schema.TestResourceDataRaw(t, aivenOpensearchRollupSchema, map[string]interface{}{})
+	if err := flattenOpensearchRollup(read, rollup); err != nil {
+		t.Fatalf("flattenOpensearchRollup() error = %v", err)
+	}
+	for _, k := range []string{"source_index", "target_index", "date_histogram", "terms", "metric", "enabled"} {
+		if !reflect.DeepEqual(read.Get(k), d.Get(k)) {
+			t.Errorf("flattenOpensearchRollup() %s = %v, want %v", k, read.Get(k), d.Get(k))
+		}
+	}
+
+	if state, err := api.rollupState(ctx, "metrics-hourly"); err != nil || state != "started" {
+		t.Errorf("rollupState() = %s, %v, want started", state, err)
+	}
+
+	if err := api.setRollupEnabled(ctx, "metrics-hourly", false); err != nil {
+		t.Fatalf("setRollupEnabled() error = %v", err)
+	}
+	if last := requests[len(requests)-1]; last != "POST /_plugins/_rollup/jobs/metrics-hourly/_stop" {
+		t.Errorf("setRollupEnabled(false) request = %s, want the job to be stopped", last)
+	}
+}
+
+func TestAccAivenOpensearchRollup_basic(t *testing.T) {
+	resourceName := "aiven_opensearch_rollup.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchRollupResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-rollup-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "rollup_id", "metrics-hourly"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "state"),
+				),
+			},
+			{
+				Config: testAccOpensearchRollupResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOpensearchRollupResource(name string, enabled bool) string {
+	return fmt.Sprintf(`
+    data "aiven_project" "foo" {
+      project = "%s"
+    }
+
+    resource "aiven_opensearch" "bar" {
+      project = data.aiven_project.foo.project
+      cloud_name = "google-europe-west1"
+      plan = "startup-4"
+      service_name = "test-acc-sr-rollup-%s"
+      maintenance_window_dow = "monday"
+      maintenance_window_time = "10:00:00"
+    }
+
+    resource "aiven_opensearch_rollup" "foo" {
+      project = data.aiven_project.foo.project
+      service_name = aiven_opensearch.bar.service_name
+      rollup_id = "metrics-hourly"
+      source_index = "metrics-*"
+      target_index = "metrics-rollup"
+      schedule_interval = 1
+      enabled = %t
+
+      date_histogram {
+        source_field = "@timestamp"
+        fixed_interval = "1h"
+      }
+
+      terms = ["host"]
+
+      metric {
+        source_field = "cpu"
+        aggregations = ["avg", "max"]
+      }
+    }`, os.Getenv("AIVEN_PROJECT_NAME"), name, enabled)
+}
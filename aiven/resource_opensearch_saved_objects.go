@@ -0,0 +1,217 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenOpensearchSavedObjectsSchema = map[string]*schema.Schema{
+	"project":      commonSchemaProjectReference,
+	"service_name": commonSchemaServiceNameReference,
+	"content": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+		Description:  "Saved objects to import in the NDJSON format of the OpenSearch Dashboards saved objects export. Changing the content re-imports the saved objects.",
+	},
+	"overwrite": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: complex("Overwrite saved objects that already exist with the same type and id. When disabled, an import that conflicts with existing saved objects fails.").defaultValue(true).build(),
+	},
+	"saved_objects": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Imported saved objects in the `type/id` format",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+}
+
+func resourceOpensearchSavedObjects() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Opensearch Saved Objects resource allows importing OpenSearch Dashboards saved objects, e.g. dashboards and index patterns, into an Aiven Opensearch service. The saved objects are imported through the OpenSearch Dashboards API of the service with its admin credentials, so the service must be reachable from where Terraform runs.",
+		CreateContext: resourceOpensearchSavedObjectsImport,
+		ReadContext:   resourceOpensearchSavedObjectsRead,
+		UpdateContext: resourceOpensearchSavedObjectsImport,
+		DeleteContext: resourceOpensearchSavedObjectsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: aivenOpensearchSavedObjectsSchema,
+	}
+}
+
+func resourceOpensearchSavedObjectsImport(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	dashboards, err := opensearchDashboardsAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	objects, err := dashboards.importSavedObjects(ctx, d.Get("content").(string), d.Get("overwrite").(bool))
+	if err != nil {
+		return diag.Errorf("cannot import saved objects to %s/%s: %s", project, serviceName, err)
+	}
+
+	// saved objects that were removed from the content are deleted on re-import
+	imported := make(map[string]bool)
+	for _, o := range objects {
+		imported[o] = true
+	}
+	for _, o := range d.Get("saved_objects").([]interface{}) {
+		if imported[o.(string)] {
+			continue
+		}
+		if err := dashboards.deleteSavedObject(ctx, o.(string)); err != nil {
+			return diag.Errorf("cannot delete saved object %s from %s/%s: %s", o, project, serviceName, err)
+		}
+	}
+
+	d.SetId(buildResourceID(project, serviceName))
+	if err := d.Set("saved_objects", objects); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceOpensearchSavedObjectsRead(ctx, d, m)
+}
+
+func resourceOpensearchSavedObjectsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName := splitResourceID2(d.Id())
+	if _, err := client.Services.Get(project, serviceName); err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchSavedObjectsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName := splitResourceID2(d.Id())
+
+	dashboards, err := opensearchDashboardsAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	for _, o := range d.Get("saved_objects").([]interface{}) {
+		if err := dashboards.deleteSavedObject(ctx, o.(string)); err != nil {
+			return diag.Errorf("cannot delete saved object %s from %s/%s: %s", o, project, serviceName, err)
+		}
+	}
+
+	return nil
+}
+
+type opensearchSavedObjectsImportResponse struct {
+	Success        bool `json:"success"`
+	SuccessCount   int  `json:"successCount"`
+	SuccessResults []struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	} `json:"successResults"`
+	Errors []struct {
+		Type  string `json:"type"`
+		ID    string `json:"id"`
+		Title string `json:"title"`
+		Error struct {
+			Type string `json:"type"`
+		} `json:"error"`
+	} `json:"errors"`
+}
+
+// importSavedObjects imports the NDJSON content and returns the imported saved objects,
+// conflicts are reported as an error unless the existing saved objects are overwritten
+func (o *serviceAPI) importSavedObjects(ctx context.Context, content string, overwrite bool) ([]string, error) {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	file, err := form.CreateFormFile("file", "saved_objects.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write([]byte(content)); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	path := "/api/saved_objects/_import"
+	if overwrite {
+		path += "?overwrite=true"
+	}
+
+	b, err := o.do(ctx, http.MethodPost, path, form.FormDataContentType(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var r opensearchSavedObjectsImportResponse
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("cannot parse import response: %s", err)
+	}
+
+	if !r.Success {
+		var conflicts, failures []string
+		for _, e := range r.Errors {
+			if e.Error.Type == "conflict" {
+				conflicts = append(conflicts, e.Type+"/"+e.ID)
+				continue
+			}
+			failures = append(failures, fmt.Sprintf("%s/%s: %s", e.Type, e.ID, e.Error.Type))
+		}
+
+		if len(conflicts) > 0 {
+			failures = append(failures, fmt.Sprintf("saved objects already exist, enable `overwrite` to replace them: %s",
+				strings.Join(conflicts, ", ")))
+		}
+
+		return nil, fmt.Errorf("import failed: %s", strings.Join(failures, "; "))
+	}
+
+	var objects []string
+	for _, s := range r.SuccessResults {
+		objects = append(objects, s.Type+"/"+s.ID)
+	}
+
+	return objects, nil
+}
+
+// deleteSavedObject deletes a saved object in the `type/id` format, a saved object
+// that is already gone is not an error
+func (o *serviceAPI) deleteSavedObject(ctx context.Context, object string) error {
+	_, err := o.do(ctx, http.MethodDelete, "/api/saved_objects/"+object, "", nil)
+	if aiven.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
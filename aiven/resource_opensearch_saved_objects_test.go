@@ -0,0 +1,144 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testOpensearchSavedObjects = `{"attributes":{"title":"logs-*","timeFieldName":"@timestamp"},"id":"logs","type":"index-pattern"}`
+
+func Test_opensearchDashboardsImportSavedObjects(t *testing.T) {
+	tests := []struct {
+		name      string
+		overwrite bool
+		response  string
+		want      []string
+		wantErr   string
+	}{
+		{
+			"imported",
+			true,
+			`{"success":true,"successCount":1,"successResults":[{"type":"index-pattern","id":"logs"}]}`,
+			[]string{"index-pattern/logs"},
+			"",
+		},
+		{
+			"conflict without overwrite",
+			false,
+			`{"success":false,"successCount":0,"errors":[{"type":"index-pattern","id":"logs","error":{"type":"conflict"}}]}`,
+			nil,
+			"enable `overwrite` to replace them: index-pattern/logs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/saved_objects/_import" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if got := r.URL.Query().Get("overwrite") == "true"; got != tt.overwrite {
+					t.Errorf("overwrite = %v, want %v", got, tt.overwrite)
+				}
+				if u, p, _ := r.BasicAuth(); u != "avnadmin" || p != "secret" {
+					t.Errorf("unexpected credentials %s:%s", u, p)
+				}
+				if r.Header.Get("osd-xsrf") == "" {
+					t.Error("missing osd-xsrf header")
+				}
+
+				file, _, err := r.FormFile("file")
+				if err != nil {
+					t.Fatalf("cannot read form file: %s", err)
+				}
+				b, _ := ioutil.ReadAll(file)
+				if string(b) != testOpensearchSavedObjects {
+					t.Errorf("unexpected content %s", b)
+				}
+
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			uri := strings.Replace(srv.URL, "http://", "http://avnadmin:secret@", 1)
+			o, err := newServiceAPI(uri, "", "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := o.importSavedObjects(context.Background(), testOpensearchSavedObjects, tt.overwrite)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("importSavedObjects() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("importSavedObjects() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("importSavedObjects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccAivenOpensearchSavedObjects_basic(t *testing.T) {
+	resourceName := "aiven_opensearch_saved_objects.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchSavedObjectsResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-os-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "saved_objects.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "saved_objects.0", "index-pattern/logs"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOpensearchSavedObjectsResource(name string) string {
+	return fmt.Sprintf(`
+    data "aiven_project" "foo" {
+      project = "%s"
+    }
+
+    resource "aiven_opensearch" "bar" {
+      project = data.aiven_project.foo.project
+      cloud_name = "google-europe-west1"
+      plan = "startup-4"
+      service_name = "test-acc-sr-os-%s"
+      maintenance_window_dow = "monday"
+      maintenance_window_time = "10:00:00"
+
+      opensearch_user_config {
+        opensearch_dashboards {
+          enabled = true
+        }
+      }
+    }
+
+    resource "aiven_opensearch_saved_objects" "foo" {
+      project = data.aiven_project.foo.project
+      service_name = aiven_opensearch.bar.service_name
+      content = <<EOT
+%s
+EOT
+    }`, os.Getenv("AIVEN_PROJECT_NAME"), name, testOpensearchSavedObjects)
+}
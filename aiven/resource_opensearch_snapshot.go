@@ -0,0 +1,251 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenOpensearchSnapshotSchema = map[string]*schema.Schema{
+	"project":      commonSchemaProjectReference,
+	"service_name": commonSchemaServiceNameReference,
+	"repository": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("Name of the snapshot repository registered on the service.").forceNew().build(),
+	},
+	"snapshot_name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  complex("Name of the snapshot.").forceNew().build(),
+	},
+	"indices": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		Description: complex("Indices or index patterns to include in the snapshot, all the indices by default.").forceNew().build(),
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "State of the snapshot, `SUCCESS` once it is complete",
+	},
+}
+
+func resourceOpensearchSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Opensearch Snapshot resource takes an on-demand snapshot of the indices of an Aiven Opensearch service to a registered snapshot repository and waits for it to complete. The snapshot is taken through the OpenSearch API of the service with its admin credentials, so the service must be reachable from where Terraform runs.",
+		CreateContext: resourceOpensearchSnapshotCreate,
+		ReadContext:   resourceOpensearchSnapshotRead,
+		DeleteContext: resourceOpensearchSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenOpensearchSnapshotSchema,
+	}
+}
+
+func resourceOpensearchSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	repository := d.Get("repository").(string)
+	snapshotName := d.Get("snapshot_name").(string)
+
+	api, err := opensearchServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var indices []string
+	for _, i := range d.Get("indices").([]interface{}) {
+		indices = append(indices, i.(string))
+	}
+
+	if err := api.createSnapshot(ctx, repository, snapshotName, indices); err != nil {
+		return diag.Errorf("cannot create snapshot %s in repository %s of %s/%s: %s",
+			snapshotName, repository, project, serviceName, err)
+	}
+
+	// the snapshot exists from now on, a failed one is tainted and deleted on the next apply
+	d.SetId(buildResourceID(project, serviceName, repository, snapshotName))
+
+	if _, err := api.waitForSnapshot(ctx, repository, snapshotName, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceOpensearchSnapshotRead(ctx, d, m)
+}
+
+func resourceOpensearchSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, repository, snapshotName := splitResourceID4(d.Id())
+
+	api, err := opensearchServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	snapshot, err := api.getSnapshot(ctx, repository, snapshotName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("repository", repository); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("snapshot_name", snapshotName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", snapshot.State); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceOpensearchSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName, repository, snapshotName := splitResourceID4(d.Id())
+
+	api, err := opensearchServiceAPI(client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	_, err = api.do(ctx, http.MethodDelete, opensearchSnapshotPath(repository, snapshotName), "", nil)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot delete snapshot %s in repository %s: %s", snapshotName, repository, err)
+	}
+
+	return nil
+}
+
+type opensearchSnapshot struct {
+	Snapshot string                      `json:"snapshot"`
+	State    string                      `json:"state"`
+	Indices  []string                    `json:"indices"`
+	Failures []opensearchSnapshotFailure `json:"failures"`
+}
+
+type opensearchSnapshotFailure struct {
+	Index  string `json:"index"`
+	Reason string `json:"reason"`
+}
+
+func opensearchSnapshotPath(repository, snapshotName string) string {
+	return "/_snapshot/" + url.PathEscape(repository) + "/" + url.PathEscape(snapshotName)
+}
+
+func (o *serviceAPI) createSnapshot(ctx context.Context, repository, snapshotName string, indices []string) error {
+	body := map[string]interface{}{}
+	if len(indices) > 0 {
+		body["indices"] = strings.Join(indices, ",")
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.do(ctx, http.MethodPut, opensearchSnapshotPath(repository, snapshotName), "application/json", bytes.NewReader(b))
+	return err
+}
+
+func (o *serviceAPI) getSnapshot(ctx context.Context, repository, snapshotName string) (*opensearchSnapshot, error) {
+	b, err := o.do(ctx, http.MethodGet, opensearchSnapshotPath(repository, snapshotName), "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Snapshots []opensearchSnapshot `json:"snapshots"`
+	}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("cannot parse snapshot: %s", err)
+	}
+	if len(r.Snapshots) == 0 {
+		return nil, aiven.Error{Message: "snapshot not found", Status: http.StatusNotFound}
+	}
+
+	return &r.Snapshots[0], nil
+}
+
+// waitForSnapshot polls a snapshot until it is complete, a partial or failed snapshot is
+// reported with the failures of its shards
+func (o *serviceAPI) waitForSnapshot(ctx context.Context, repository, snapshotName string, timeout time.Duration) (*opensearchSnapshot, error) {
+	conf := &resource.StateChangeConf{
+		Pending: []string{"IN_PROGRESS"},
+		Target:  []string{"SUCCESS"},
+		Refresh: func() (interface{}, string, error) {
+			snapshot, err := o.getSnapshot(ctx, repository, snapshotName)
+			if err != nil {
+				return nil, "", err
+			}
+
+			switch snapshot.State {
+			case "IN_PROGRESS", "SUCCESS":
+				return snapshot, snapshot.State, nil
+			default:
+				return nil, "", opensearchSnapshotError(repository, snapshot)
+			}
+		},
+		Timeout:    timeout,
+		MinTimeout: time.Second,
+	}
+
+	snapshot, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.(*opensearchSnapshot), nil
+}
+
+func opensearchSnapshotError(repository string, snapshot *opensearchSnapshot) error {
+	var failures []string
+	for _, f := range snapshot.Failures {
+		failures = append(failures, fmt.Sprintf("%s: %s", f.Index, f.Reason))
+	}
+
+	switch snapshot.State {
+	case "PARTIAL":
+		return fmt.Errorf("snapshot %s in repository %s is partial, some shards could not be stored: %s",
+			snapshot.Snapshot, repository, strings.Join(failures, "; "))
+	case "FAILED":
+		return fmt.Errorf("snapshot %s in repository %s failed: %s",
+			snapshot.Snapshot, repository, strings.Join(failures, "; "))
+	default:
+		return fmt.Errorf("snapshot %s in repository %s is in unexpected state %s",
+			snapshot.Snapshot, repository, snapshot.State)
+	}
+}
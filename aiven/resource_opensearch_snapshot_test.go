@@ -0,0 +1,132 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_opensearchSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		states  []string
+		wantErr string
+	}{
+		{
+			"success",
+			[]string{"IN_PROGRESS", "SUCCESS"},
+			"",
+		},
+		{
+			"partial",
+			[]string{"IN_PROGRESS", "PARTIAL"},
+			"snapshot before-reindex in repository backups is partial, some shards could not be stored: logs: node left",
+		},
+		{
+			"failed",
+			[]string{"FAILED"},
+			"snapshot before-reindex in repository backups failed: logs: node left",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/_snapshot/backups/before-reindex" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+
+				switch r.Method {
+				case http.MethodPut:
+					b, _ := ioutil.ReadAll(r.Body)
+					if string(b) != `{"indices":"logs,metrics-*"}` {
+						t.Errorf("unexpected snapshot request %s", b)
+					}
+					_, _ = w.Write([]byte(`{"accepted":true}`))
+				case http.MethodGet:
+					state := tt.states[polls]
+					if polls < len(tt.states)-1 {
+						polls++
+					}
+					_, _ = fmt.Fprintf(w, `{"snapshots":[{"snapshot":"before-reindex","state":"%s",`+
+						`"indices":["logs","metrics-1"],"failures":[{"index":"logs","reason":"node left"}]}]}`, state)
+				}
+			}))
+			defer srv.Close()
+
+			api, err := newServiceAPI(srv.URL, "avnadmin", "secret", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			if err := api.createSnapshot(ctx, "backups", "before-reindex", []string{"logs", "metrics-*"}); err != nil {
+				t.Fatalf("createSnapshot() error = %v", err)
+			}
+
+			snapshot, err := api.waitForSnapshot(ctx, "backups", "before-reindex", time.Minute)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("waitForSnapshot() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("waitForSnapshot() error = %v", err)
+			}
+			if snapshot.State != "SUCCESS" {
+				t.Errorf("waitForSnapshot() state = %s, want SUCCESS", snapshot.State)
+			}
+		})
+	}
+}
+
+func TestAccAivenOpensearchSnapshot_basic(t *testing.T) {
+	// snapshot repositories are registered out of band, an existing service is used
+	if os.Getenv("AIVEN_OPENSEARCH_SNAPSHOT_SERVICE") == "" ||
+		os.Getenv("AIVEN_OPENSEARCH_SNAPSHOT_REPOSITORY") == "" {
+		t.Skip("AIVEN_OPENSEARCH_SNAPSHOT_SERVICE and AIVEN_OPENSEARCH_SNAPSHOT_REPOSITORY env variables are required to run this test")
+	}
+
+	resourceName := "aiven_opensearch_snapshot.foo"
+	snapshotName := fmt.Sprintf("test-acc-snapshot-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchSnapshotResource(snapshotName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", os.Getenv("AIVEN_OPENSEARCH_SNAPSHOT_SERVICE")),
+					resource.TestCheckResourceAttr(resourceName, "snapshot_name", snapshotName),
+					resource.TestCheckResourceAttr(resourceName, "state", "SUCCESS"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOpensearchSnapshotResource(snapshotName string) string {
+	return fmt.Sprintf(`
+    resource "aiven_opensearch_snapshot" "foo" {
+      project = "%s"
+      service_name = "%s"
+      repository = "%s"
+      snapshot_name = "%s"
+    }`,
+		os.Getenv("AIVEN_PROJECT_NAME"),
+		os.Getenv("AIVEN_OPENSEARCH_SNAPSHOT_SERVICE"),
+		os.Getenv("AIVEN_OPENSEARCH_SNAPSHOT_REPOSITORY"),
+		snapshotName)
+}
@@ -3,14 +3,218 @@ package aiven
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func Test_expandOpensearchIndexTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			"shards and replicas",
+			map[string]interface{}{
+				"opensearch_index_template": []interface{}{
+					map[string]interface{}{"number_of_shards": 3, "number_of_replicas": 2},
+				},
+			},
+			map[string]interface{}{"max_index_count": 10},
+			map[string]interface{}{
+				"max_index_count": 10,
+				"index_template": map[string]interface{}{
+					"mapping_nested_objects_limit": 10000,
+					"number_of_replicas":           2,
+					"number_of_shards":             3,
+				},
+			},
+		},
+		{
+			"no replicas",
+			map[string]interface{}{
+				"opensearch_index_template": []interface{}{
+					map[string]interface{}{"number_of_replicas": 0},
+				},
+			},
+			nil,
+			map[string]interface{}{
+				"index_template": map[string]interface{}{
+					"mapping_nested_objects_limit": 10000,
+					"number_of_replicas":           0,
+					"number_of_shards":             1,
+				},
+			},
+		},
+		{
+			"unset",
+			map[string]interface{}{},
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, opensearchSchema(), tt.raw)
+			if got := expandOpensearchIndexTemplate(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandOpensearchIndexTemplate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expandOpensearchIndexTemplateUpdate(t *testing.T) {
+	state := map[string]string{
+		"project":                     "test-project",
+		"service_name":                "test-service",
+		"opensearch_index_template.#": "1",
+		"opensearch_index_template.0.mapping_nested_objects_limit":   "10000",
+		"opensearch_index_template.0.number_of_replicas":             "1",
+		"opensearch_index_template.0.number_of_shards":               "1",
+		"opensearch_user_config.#":                                   "1",
+		"opensearch_user_config.0.index_template.#":                  "1",
+		"opensearch_user_config.0.index_template.0.number_of_shards": "1",
+	}
+
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			// the block keeps the template read back, which must not override the nested key
+			"nested key changed",
+			map[string]interface{}{
+				"project":      "test-project",
+				"service_name": "test-service",
+				"opensearch_user_config": []interface{}{map[string]interface{}{
+					"index_template": []interface{}{map[string]interface{}{"number_of_shards": "3"}},
+				}},
+			},
+			map[string]interface{}{"index_template": map[string]interface{}{"number_of_shards": 3}},
+			map[string]interface{}{"index_template": map[string]interface{}{"number_of_shards": 3}},
+		},
+		{
+			"block changed",
+			map[string]interface{}{
+				"project":      "test-project",
+				"service_name": "test-service",
+				"opensearch_index_template": []interface{}{
+					map[string]interface{}{"number_of_shards": 3},
+				},
+			},
+			nil,
+			map[string]interface{}{
+				"index_template": map[string]interface{}{
+					"mapping_nested_objects_limit": 10000,
+					"number_of_replicas":           1,
+					"number_of_shards":             3,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testResourceDataUpdate(t, resourceOpensearch(), state, tt.raw)
+			if got := expandOpensearchIndexTemplate(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandOpensearchIndexTemplate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flattenOpensearchIndexTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		userConfig map[string]interface{}
+		want       []map[string]interface{}
+	}{
+		{
+			"partial template",
+			map[string]interface{}{
+				"index_template": map[string]interface{}{"number_of_shards": float64(3)},
+			},
+			[]map[string]interface{}{{
+				"mapping_nested_objects_limit": 10000,
+				"number_of_replicas":           1,
+				"number_of_shards":             3,
+			}},
+		},
+		{
+			"no template",
+			map[string]interface{}{"max_index_count": float64(10)},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flattenOpensearchIndexTemplate(tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flattenOpensearchIndexTemplate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccAivenService_osIndexTemplate(t *testing.T) {
+	resourceName := "aiven_opensearch.bar-os"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchIndexTemplateResource(rName, 3, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "opensearch_index_template.0.number_of_shards", "3"),
+					resource.TestCheckResourceAttr(resourceName, "opensearch_index_template.0.number_of_replicas", "2"),
+					resource.TestCheckResourceAttr(resourceName, "opensearch_user_config.0.index_template.0.number_of_shards", "3"),
+					resource.TestCheckResourceAttr(resourceName, "opensearch_user_config.0.index_template.0.number_of_replicas", "2"),
+				),
+			},
+			{
+				Config: testAccOpensearchIndexTemplateResource(rName, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "opensearch_index_template.0.number_of_shards", "1"),
+					resource.TestCheckResourceAttr(resourceName, "opensearch_index_template.0.number_of_replicas", "0"),
+					resource.TestCheckResourceAttr(resourceName, "opensearch_user_config.0.index_template.0.number_of_replicas", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOpensearchIndexTemplateResource(name string, shards, replicas int) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_opensearch" "bar-os" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+
+			opensearch_index_template {
+				number_of_shards = %d
+				number_of_replicas = %d
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, shards, replicas)
+}
+
 // Opensearch service tests
 func TestAccAivenService_os(t *testing.T) {
 	resourceName := "aiven_opensearch.bar-os"
@@ -3,12 +3,14 @@ package aiven
 import (
 	"context"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func aivenPGSchema() map[string]*schema.Schema {
@@ -69,6 +71,14 @@ func aivenPGSchema() map[string]*schema.Schema {
 		},
 	}
 	schemaPG[ServiceTypePG+"_user_config"] = generateServiceUserConfiguration(ServiceTypePG)
+	schemaPG["wait_for_read_replica"] = serviceWaitForReadReplicaSchema()
+	for k, v := range pgMemorySettingsSchema() {
+		schemaPG[k] = v
+	}
+	for k, v := range pgBouncerSettingsSchema() {
+		schemaPG[k] = v
+	}
+	schemaPG["connection_pools"] = pgConnectionPoolsSchema()
 
 	return schemaPG
 }
@@ -83,9 +93,11 @@ func resourcePG() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypePG),
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(20 * time.Minute),
 			Update:  schema.DefaultTimeout(20 * time.Minute),
+			Delete:  schema.DefaultTimeout(20 * time.Minute),
 			Default: schema.DefaultTimeout(5 * time.Minute),
 		},
 
@@ -94,7 +106,7 @@ func resourcePG() *schema.Resource {
 }
 
 func resourceServicePGUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
 	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("service", "pg", false, d)
@@ -115,7 +127,7 @@ func resourceServicePGUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			}
 
 			w := &ServiceTaskWaiter{
-				Client:      m.(*aiven.Client),
+				Client:      m.(*providerMeta).client,
 				Project:     projectName,
 				ServiceName: serviceName,
 				TaskId:      t.Task.Id,
@@ -181,3 +193,187 @@ func (w *ServiceTaskWaiter) Conf(timeout time.Duration) *resource.StateChangeCon
 		ContinuousTargetOccurence: 3,
 	}
 }
+
+func pgConnectionPoolsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "PgBouncer connection pools of the service, managed with `aiven_connection_pool` resources",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pool_name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the pool",
+				},
+				"database_name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the database the pool connects to",
+				},
+				"pool_mode": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The mode the pool operates in",
+				},
+				"pool_size": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of connections the pool may create towards the backend server",
+				},
+				"username": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the service user used to connect to the database",
+				},
+				"connection_uri": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The URI for connecting to the pool",
+					Sensitive:   true,
+				},
+			},
+		},
+	}
+}
+
+// flattenPGConnectionPools lists the connection pools of a service sorted by name
+func flattenPGConnectionPools(pools []*aiven.ConnectionPool) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, p := range pools {
+		result = append(result, map[string]interface{}{
+			"pool_name":      p.PoolName,
+			"database_name":  p.Database,
+			"pool_mode":      p.PoolMode,
+			"pool_size":      p.PoolSize,
+			"username":       p.Username,
+			"connection_uri": p.ConnectionURI,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i]["pool_name"].(string) < result[j]["pool_name"].(string)
+	})
+
+	return result
+}
+
+// pgMemorySettings maps the top-level PG memory fields to their pg_user_config key
+var pgMemorySettings = map[string]string{
+	"pg_shared_buffers_percentage": "shared_buffers_percentage",
+	"pg_work_mem":                  "work_mem",
+}
+
+func pgMemorySettingsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"pg_shared_buffers_percentage": {
+			Type:          schema.TypeFloat,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.FloatBetween(20, 60),
+			ConflictsWith: []string{"pg_user_config.0.shared_buffers_percentage"},
+			Description:   "Percentage of total RAM that the database server uses for shared memory buffers, between 20 and 60. Shorthand for `pg_user_config.shared_buffers_percentage`.",
+		},
+		"pg_work_mem": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.IntBetween(1, 1024),
+			ConflictsWith: []string{"pg_user_config.0.work_mem"},
+			Description:   "Maximum amount of memory in MB used by a query operation before writing to temporary disk files, between 1 and 1024. Shorthand for `pg_user_config.work_mem`.",
+		},
+	}
+}
+
+// expandPGMemorySettings adds the top-level PG memory fields that are set to the user config
+// sent to the API, see userConfigShorthand
+func expandPGMemorySettings(d *schema.ResourceData, userConfig map[string]interface{}) map[string]interface{} {
+	for field, key := range pgMemorySettings {
+		v, ok := userConfigShorthand(d, field)
+		if !ok {
+			continue
+		}
+		if userConfig == nil {
+			userConfig = make(map[string]interface{})
+		}
+		userConfig[key] = v
+	}
+
+	return userConfig
+}
+
+// flattenPGMemorySettings sets the top-level PG memory fields from the service user config
+func flattenPGMemorySettings(d *schema.ResourceData, userConfig map[string]interface{}) error {
+	if err := d.Set("pg_shared_buffers_percentage", userConfig["shared_buffers_percentage"]); err != nil {
+		return err
+	}
+
+	var workMem interface{}
+	if v, ok := userConfig["work_mem"].(float64); ok {
+		workMem = int(v)
+	}
+	return d.Set("pg_work_mem", workMem)
+}
+
+// pgBouncerSettings maps the top-level PgBouncer fields to their pg_user_config.pgbouncer key
+var pgBouncerSettings = map[string]string{
+	"pgbouncer_min_pool_size":             "min_pool_size",
+	"pgbouncer_server_reset_query_always": "server_reset_query_always",
+}
+
+func pgBouncerSettingsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"pgbouncer_min_pool_size": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Computed:      true,
+			ValidateFunc:  validation.IntBetween(0, 10000),
+			ConflictsWith: []string{"pg_user_config.0.pgbouncer.0.min_pool_size"},
+			Description:   "Number of server connections PgBouncer keeps in each pool when the load comes back after a period of inactivity, between 0 and 10000. Shorthand for `pg_user_config.pgbouncer.min_pool_size`.",
+		},
+		"pgbouncer_server_reset_query_always": {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"pg_user_config.0.pgbouncer.0.server_reset_query_always"},
+			Description:   "Runs the server reset query (`DISCARD ALL`) in all the pooling modes of PgBouncer. Shorthand for `pg_user_config.pgbouncer.server_reset_query_always`.",
+		},
+	}
+}
+
+// expandPGBouncerSettings adds the top-level PgBouncer fields that are set to the nested
+// pgbouncer settings of the user config sent to the API, see userConfigShorthand
+func expandPGBouncerSettings(d *schema.ResourceData, userConfig map[string]interface{}) map[string]interface{} {
+	for field, key := range pgBouncerSettings {
+		v, ok := userConfigShorthand(d, field)
+		if !ok {
+			continue
+		}
+		if userConfig == nil {
+			userConfig = make(map[string]interface{})
+		}
+		pgbouncer, ok := userConfig["pgbouncer"].(map[string]interface{})
+		if !ok {
+			pgbouncer = make(map[string]interface{})
+			userConfig["pgbouncer"] = pgbouncer
+		}
+		pgbouncer[key] = v
+	}
+
+	return userConfig
+}
+
+// flattenPGBouncerSettings sets the top-level PgBouncer fields from the service user config
+func flattenPGBouncerSettings(d *schema.ResourceData, userConfig map[string]interface{}) error {
+	pgbouncer, _ := userConfig["pgbouncer"].(map[string]interface{})
+
+	var minPoolSize interface{}
+	if v, ok := pgbouncer["min_pool_size"].(float64); ok {
+		minPoolSize = int(v)
+	}
+	if err := d.Set("pgbouncer_min_pool_size", minPoolSize); err != nil {
+		return err
+	}
+
+	return d.Set("pgbouncer_server_reset_query_always", pgbouncer["server_reset_query_always"])
+}
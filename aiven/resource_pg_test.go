@@ -3,12 +3,132 @@ package aiven
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"regexp"
 	"testing"
 
+	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func Test_flattenPGConnectionPools(t *testing.T) {
+	got := flattenPGConnectionPools([]*aiven.ConnectionPool{
+		{PoolName: "reports", Database: "defaultdb", PoolMode: "session", PoolSize: 5, Username: "reporter", ConnectionURI: "postgres://reporter@test:6543/reports"},
+		{PoolName: "app", Database: "defaultdb", PoolMode: "transaction", PoolSize: 25, Username: "avnadmin", ConnectionURI: "postgres://avnadmin@test:6543/app"},
+	})
+	want := []map[string]interface{}{
+		{"pool_name": "app", "database_name": "defaultdb", "pool_mode": "transaction", "pool_size": 25, "username": "avnadmin", "connection_uri": "postgres://avnadmin@test:6543/app"},
+		{"pool_name": "reports", "database_name": "defaultdb", "pool_mode": "session", "pool_size": 5, "username": "reporter", "connection_uri": "postgres://reporter@test:6543/reports"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenPGConnectionPools() = %v, want %v", got, want)
+	}
+}
+
+func Test_expandPGMemorySettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			"shared buffers",
+			map[string]interface{}{"pg_shared_buffers_percentage": 41.5},
+			nil,
+			map[string]interface{}{"shared_buffers_percentage": 41.5},
+		},
+		{
+			"work mem",
+			map[string]interface{}{"pg_work_mem": 16},
+			map[string]interface{}{"pg_version": "13"},
+			map[string]interface{}{"pg_version": "13", "work_mem": 16},
+		},
+		{
+			"unset",
+			map[string]interface{}{},
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, aivenPGSchema(), tt.raw)
+			if got := expandPGMemorySettings(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandPGMemorySettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expandPGMemorySettingsUpdate(t *testing.T) {
+	state := map[string]string{
+		"project":                      "test-project",
+		"service_name":                 "test-service",
+		"pg_work_mem":                  "16",
+		"pg_shared_buffers_percentage": "41.5",
+		"pg_user_config.#":             "1",
+		"pg_user_config.0.work_mem":    "16",
+		"pg_user_config.0.shared_buffers_percentage": "41.5",
+	}
+
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			// the shorthands keep the values read back, which must not override the nested key
+			"nested key changed",
+			map[string]interface{}{
+				"project":        "test-project",
+				"service_name":   "test-service",
+				"pg_user_config": []interface{}{map[string]interface{}{"work_mem": "32", "shared_buffers_percentage": "41.5"}},
+			},
+			map[string]interface{}{"work_mem": 32, "shared_buffers_percentage": 41.5},
+			map[string]interface{}{"work_mem": 32, "shared_buffers_percentage": 41.5},
+		},
+		{
+			"shorthand changed",
+			map[string]interface{}{
+				"project":      "test-project",
+				"service_name": "test-service",
+				"pg_work_mem":  32,
+			},
+			nil,
+			map[string]interface{}{"work_mem": 32},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testResourceDataUpdate(t, resourcePG(), state, tt.raw)
+			if got := expandPGMemorySettings(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandPGMemorySettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flattenPGMemorySettings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenPGSchema(), map[string]interface{}{})
+	err := flattenPGMemorySettings(d, map[string]interface{}{
+		"shared_buffers_percentage": 41.5,
+		"work_mem":                  float64(16),
+	})
+	if err != nil {
+		t.Fatalf("flattenPGMemorySettings() error = %v", err)
+	}
+	if got := d.Get("pg_shared_buffers_percentage"); got != 41.5 {
+		t.Errorf("pg_shared_buffers_percentage = %v, want 41.5", got)
+	}
+	if got := d.Get("pg_work_mem"); got != 16 {
+		t.Errorf("pg_work_mem = %v, want 16", got)
+	}
+}
+
 func TestAccAiven_pg(t *testing.T) {
 	resourceName := "aiven_pg.bar"
 	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
@@ -27,6 +147,8 @@ func TestAccAiven_pg(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
 					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
 					resource.TestCheckResourceAttr(resourceName, "service_type", "pg"),
+					resource.TestCheckResourceAttrPair(resourceName, "env_vars.PGHOST", resourceName, "service_host"),
+					resource.TestCheckResourceAttrPair(resourceName, "env_vars.PGPORT", resourceName, "service_port"),
 					resource.TestCheckResourceAttr(resourceName, "cloud_name", "google-europe-west1"),
 					resource.TestCheckResourceAttr(resourceName, "maintenance_window_dow", "monday"),
 					resource.TestCheckResourceAttr(resourceName, "maintenance_window_time", "10:00:00"),
@@ -73,3 +195,514 @@ func testAccPGResource(name string) string {
 		}
 		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
 }
+
+func TestAccAiven_pg_terminationProtectionDrift(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	serviceName := fmt.Sprintf("test-acc-sr-%s", rName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPGTerminationProtectionResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "termination_protection", "true"),
+				),
+			},
+			{
+				// termination protection disabled out-of-band must show up in the plan
+				PreConfig: func() {
+					testAccSetServiceTerminationProtection(t, os.Getenv("AIVEN_PROJECT_NAME"), serviceName, false)
+				},
+				Config:             testAccPGTerminationProtectionResource(rName, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccPGTerminationProtectionResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "termination_protection", "true"),
+				),
+			},
+			{
+				Config: testAccPGTerminationProtectionResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "termination_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSetServiceTerminationProtection(t *testing.T, project, serviceName string, enabled bool) {
+	client := testAccProvider.Meta().(*providerMeta).client
+
+	_, err := client.Services.Update(project, serviceName, aiven.UpdateServiceRequest{
+		Powered:               true,
+		TerminationProtection: enabled,
+	})
+	if err != nil {
+		t.Fatalf("cannot update termination protection of service %s: %s", serviceName, err)
+	}
+}
+
+func testAccPGTerminationProtectionResource(name string, terminationProtection bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			termination_protection = %t
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, terminationProtection)
+}
+
+func TestAccAiven_pg_renameByFork(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPGRenameResource(rName, "test-acc-sr-"+rName, true),
+			},
+			{
+				// renaming a protected service in place is refused at plan time
+				Config:      testAccPGRenameResource(rName, "test-acc-sr-renamed-"+rName, true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Aiven services cannot be renamed"),
+			},
+			{
+				Config: testAccPGRenameByForkResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_pg.renamed", "service_name", "test-acc-sr-renamed-"+rName),
+					resource.TestCheckResourceAttr("aiven_pg.renamed", "state", "RUNNING"),
+					resource.TestCheckResourceAttr("aiven_pg.renamed", "pg_user_config.0.service_to_fork_from", "test-acc-sr-"+rName),
+					resource.TestCheckResourceAttr("data.aiven_service_fork.renamed", "forked_from", "test-acc-sr-"+rName),
+					resource.TestCheckResourceAttr("data.aiven_service_fork.renamed", "ready", "true"),
+				),
+			},
+			{
+				Config: testAccPGRenameResource(rName, "test-acc-sr-"+rName, false),
+			},
+		},
+	})
+}
+
+func testAccPGRenameResource(name, serviceName string, terminationProtection bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "%s"
+			termination_protection = %t
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), serviceName, terminationProtection)
+}
+
+func testAccPGRenameByForkResource(name string) string {
+	return testAccPGRenameResource(name, "test-acc-sr-"+name, true) + fmt.Sprintf(`
+		resource "aiven_pg" "renamed" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-renamed-%s"
+
+			pg_user_config {
+				service_to_fork_from = aiven_pg.bar.service_name
+			}
+		}
+
+		data "aiven_service_fork" "renamed" {
+			project = aiven_pg.renamed.project
+			service_name = aiven_pg.renamed.service_name
+			source_service_name = aiven_pg.bar.service_name
+		}
+		`, name)
+}
+
+func TestAccAiven_pg_adoptExisting(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	serviceName := fmt.Sprintf("test-acc-sr-%s", rName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the service exists already, e.g. after a create that timed out
+				PreConfig: func() {
+					// the provider is not configured before the first step
+					client, err := aiven.NewTokenClient(os.Getenv("AIVEN_TOKEN"), "terraform-provider-aiven-acc/")
+					if err != nil {
+						t.Fatal(err)
+					}
+					_, err = client.Services.Create(os.Getenv("AIVEN_PROJECT_NAME"), aiven.CreateServiceRequest{
+						Cloud:       "google-europe-west1",
+						Plan:        "startup-4",
+						ServiceName: serviceName,
+						ServiceType: ServiceTypePG,
+					})
+					if err != nil {
+						t.Fatalf("cannot create service %s: %s", serviceName, err)
+					}
+				},
+				Config:      testAccPGAdoptExistingResource(rName, false),
+				ExpectError: regexp.MustCompile("already exists, import it or set adopt_existing"),
+			},
+			{
+				Config: testAccPGAdoptExistingResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "service_name", serviceName),
+					resource.TestCheckResourceAttr(resourceName, "adopt_existing", "true"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPGAdoptExistingResource(name string, adopt bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			adopt_existing = %t
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, adopt)
+}
+
+func Test_expandPGBouncerSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			"min pool size",
+			map[string]interface{}{"pgbouncer_min_pool_size": 10},
+			nil,
+			map[string]interface{}{"pgbouncer": map[string]interface{}{"min_pool_size": 10}},
+		},
+		{
+			"merged with the user config",
+			map[string]interface{}{"pgbouncer_server_reset_query_always": true},
+			map[string]interface{}{"pgbouncer": map[string]interface{}{"autodb_pool_mode": "transaction"}},
+			map[string]interface{}{"pgbouncer": map[string]interface{}{"autodb_pool_mode": "transaction", "server_reset_query_always": true}},
+		},
+		{
+			"unset",
+			map[string]interface{}{},
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, aivenPGSchema(), tt.raw)
+			if got := expandPGBouncerSettings(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandPGBouncerSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expandPGBouncerSettingsUpdate(t *testing.T) {
+	state := map[string]string{
+		"project":                      "test-project",
+		"service_name":                 "test-service",
+		"pgbouncer_min_pool_size":      "10",
+		"pg_user_config.#":             "1",
+		"pg_user_config.0.pgbouncer.#": "1",
+		"pg_user_config.0.pgbouncer.0.min_pool_size": "10",
+	}
+
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		userConfig map[string]interface{}
+		want       map[string]interface{}
+	}{
+		{
+			// the shorthand keeps the value read back, which must not override the nested key
+			"nested key changed",
+			map[string]interface{}{
+				"project":      "test-project",
+				"service_name": "test-service",
+				"pg_user_config": []interface{}{map[string]interface{}{
+					"pgbouncer": []interface{}{map[string]interface{}{"min_pool_size": "20"}},
+				}},
+			},
+			map[string]interface{}{"pgbouncer": map[string]interface{}{"min_pool_size": 20}},
+			map[string]interface{}{"pgbouncer": map[string]interface{}{"min_pool_size": 20}},
+		},
+		{
+			"shorthand changed",
+			map[string]interface{}{
+				"project":                 "test-project",
+				"service_name":            "test-service",
+				"pgbouncer_min_pool_size": 20,
+			},
+			nil,
+			map[string]interface{}{"pgbouncer": map[string]interface{}{"min_pool_size": 20}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testResourceDataUpdate(t, resourcePG(), state, tt.raw)
+			if got := expandPGBouncerSettings(d, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandPGBouncerSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flattenPGBouncerSettings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenPGSchema(), map[string]interface{}{})
+	err := flattenPGBouncerSettings(d, map[string]interface{}{
+		"pgbouncer": map[string]interface{}{
+			"min_pool_size":             float64(10),
+			"server_reset_query_always": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("flattenPGBouncerSettings() error = %v", err)
+	}
+	if got := d.Get("pgbouncer_min_pool_size"); got != 10 {
+		t.Errorf("pgbouncer_min_pool_size = %v, want 10", got)
+	}
+	if got := d.Get("pgbouncer_server_reset_query_always"); got != true {
+		t.Errorf("pgbouncer_server_reset_query_always = %v, want true", got)
+	}
+}
+
+func TestAccAiven_pg_memorySettings(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPGMemorySettingsResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "pg_shared_buffers_percentage", "41.5"),
+					resource.TestCheckResourceAttr(resourceName, "pg_work_mem", "16"),
+					resource.TestCheckResourceAttr(resourceName, "pg_user_config.0.shared_buffers_percentage", "41.5"),
+					resource.TestCheckResourceAttr(resourceName, "pg_user_config.0.work_mem", "16"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPGMemorySettingsResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+			pg_shared_buffers_percentage = 41.5
+			pg_work_mem = 16
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
+
+func TestAccAiven_pg_pgBouncerSettings(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPGBouncerSettingsResource(rName, 10, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "pgbouncer_min_pool_size", "10"),
+					resource.TestCheckResourceAttr(resourceName, "pgbouncer_server_reset_query_always", "true"),
+					resource.TestCheckResourceAttr(resourceName, "pg_user_config.0.pgbouncer.0.min_pool_size", "10"),
+					resource.TestCheckResourceAttr(resourceName, "pg_user_config.0.pgbouncer.0.server_reset_query_always", "true"),
+				),
+			},
+			{
+				// the zero values are sent to reset the settings
+				Config: testAccPGBouncerSettingsResource(rName, 0, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "pgbouncer_min_pool_size", "0"),
+					resource.TestCheckResourceAttr(resourceName, "pgbouncer_server_reset_query_always", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPGBouncerSettingsResource(name string, minPoolSize int, serverResetQueryAlways bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+			pgbouncer_min_pool_size = %d
+			pgbouncer_server_reset_query_always = %t
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, minPoolSize, serverResetQueryAlways)
+}
+
+func TestAccAiven_pg_powered(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPGPoweredResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "powered", "true"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+			{
+				// the short update timeout fails the apply if the waiter keeps waiting for RUNNING
+				Config: testAccPGPoweredResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "powered", "false"),
+					resource.TestCheckResourceAttr(resourceName, "state", "POWEROFF"),
+				),
+			},
+			{
+				Config:   testAccPGPoweredResource(rName, false),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccPGPoweredResource(name string, powered bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+			powered = %t
+
+			timeouts {
+				update = "5m"
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, powered)
+}
+
+func TestAccAiven_pg_cloudAndVPCMigration(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPGCloudAndVPCResource(rName, "west1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cloud_name", "google-europe-west1"),
+					resource.TestCheckResourceAttrPair(resourceName, "project_vpc_id", "aiven_project_vpc.west1", "id"),
+				),
+			},
+			{
+				// both are checked right after the update, the waiter returns only once the
+				// service reflects the new cloud and the new VPC
+				Config: testAccPGCloudAndVPCResource(rName, "west2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cloud_name", "google-europe-west2"),
+					resource.TestCheckResourceAttrPair(resourceName, "project_vpc_id", "aiven_project_vpc.west2", "id"),
+				),
+			},
+			{
+				Config:   testAccPGCloudAndVPCResource(rName, "west2"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccPGCloudAndVPCResource(name, region string) string {
+	return fmt.Sprintf(`
+		resource "aiven_project" "foo" {
+			project = "test-acc-pr-%s"
+		}
+
+		resource "aiven_project_vpc" "west1" {
+			project = aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			network_cidr = "192.168.0.0/24"
+		}
+
+		resource "aiven_project_vpc" "west2" {
+			project = aiven_project.foo.project
+			cloud_name = "google-europe-west2"
+			network_cidr = "192.168.1.0/24"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = aiven_project.foo.project
+			cloud_name = aiven_project_vpc.%s.cloud_name
+			project_vpc_id = aiven_project_vpc.%s.id
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			maintenance_window_dow = "monday"
+			maintenance_window_time = "10:00:00"
+		}
+		`, name, region, region, name)
+}
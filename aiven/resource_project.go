@@ -156,7 +156,7 @@ func resourceProject() *schema.Resource {
 }
 
 func resourceProjectCreate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	cardID, err := getLongCardID(client, d.Get("card_id").(string))
 	if err != nil {
 		return diag.Errorf("Error getting long card id: %s", err)
@@ -288,7 +288,7 @@ func resourceProjectAssignToBillingGroup(
 }
 
 func resourceProjectRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, err := client.Projects.Get(d.Id())
 	if err != nil {
@@ -340,7 +340,7 @@ func resourceProjectRead(_ context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceProjectUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	cardID, err := getLongCardID(client, d.Get("card_id").(string))
 	if err != nil {
@@ -402,7 +402,7 @@ func resourceProjectUpdate(_ context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceProjectDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.Projects.Delete(d.Id())
 
@@ -427,7 +427,7 @@ func resourceProjectDelete(_ context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceProjectState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, err := client.Projects.Get(d.Id())
 	if err != nil {
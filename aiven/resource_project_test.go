@@ -216,7 +216,7 @@ func testAccCheckAivenProjectAttributes(n string, attributes ...string) resource
 }
 
 func testAccCheckAivenProjectResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each project is destroyed
 	for _, rs := range s.RootModule().Resources {
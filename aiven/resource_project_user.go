@@ -47,7 +47,7 @@ func resourceProjectUser() *schema.Resource {
 }
 
 func resourceProjectUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName := d.Get("project").(string)
 	email := d.Get("email").(string)
 	err := client.ProjectUsers.Invite(
@@ -70,7 +70,7 @@ func resourceProjectUserCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceProjectUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, email := splitResourceID2(d.Id())
 	user, invitation, err := client.ProjectUsers.Get(projectName, email)
@@ -106,7 +106,7 @@ func resourceProjectUserRead(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceProjectUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, email := splitResourceID2(d.Id())
 	memberType := d.Get("member_type").(string)
@@ -125,7 +125,7 @@ func resourceProjectUserUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceProjectUserDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, email := splitResourceID2(d.Id())
 	user, invitation, err := client.ProjectUsers.Get(projectName, email)
@@ -55,7 +55,7 @@ func resourceProjectVPC() *schema.Resource {
 }
 
 func resourceProjectVPCCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName := d.Get("project").(string)
 	vpc, err := client.VPCs.Create(
 		projectName,
@@ -87,7 +87,7 @@ func resourceProjectVPCCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceProjectVPCRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, vpcID := splitResourceID2(d.Id())
 	vpc, err := client.VPCs.Get(projectName, vpcID)
@@ -104,7 +104,7 @@ func resourceProjectVPCRead(_ context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceProjectVPCDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, vpcID := splitResourceID2(d.Id())
 
@@ -13,10 +13,19 @@ func redisSchema() map[string]*schema.Schema {
 		Computed:    true,
 		Description: "Redis server provided values",
 		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{},
+			Schema: map[string]*schema.Schema{
+				"replica_uris": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Redis replica URIs for read-only access, for services with replicas",
+					Sensitive:   true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
 		},
 	}
 	s[ServiceTypeRedis+"_user_config"] = generateServiceUserConfiguration(ServiceTypeRedis)
+	s["wait_for_read_replica"] = serviceWaitForReadReplicaSchema()
 
 	return s
 }
@@ -31,9 +40,11 @@ func resourceRedis() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeRedis),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: redisSchema(),
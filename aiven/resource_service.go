@@ -4,13 +4,23 @@ package aiven
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -32,6 +42,60 @@ const (
 	ServiceTypeFlink            = "flink"
 )
 
+// maintenanceWindowDays are the days of the maintenance window of a service, never disables
+// the maintenance of the service
+var maintenanceWindowDays = []string{
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday", "never",
+}
+
+// validateMaintenanceWindowDow accepts an empty day too, its diff is suppressed to keep the
+// maintenance window of the service
+var validateMaintenanceWindowDow = validation.Any(
+	validation.StringIsEmpty,
+	validation.StringInSlice(maintenanceWindowDays, false),
+)
+
+// validateMaintenanceWindowTime is a ValidateFunc that ensures a string is a time of day in
+// HH:mm:ss format, an empty time is accepted like an empty day
+func validateMaintenanceWindowTime(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	t, err := time.Parse("15:04:05", value)
+	if err != nil || t.Format("15:04:05") != value {
+		errors = append(errors, fmt.Errorf("%q: invalid time %q, expected a UTC time in HH:mm:ss format, e.g. 10:00:00", k, value))
+	}
+
+	return
+}
+
+// defaultServicePlans are the minimal plans used for a service created without a plan, the list
+// is static since the client has no access to the service plans catalog of the API
+var defaultServicePlans = map[string]string{
+	ServiceTypePG:               "startup-4",
+	ServiceTypeCassandra:        "startup-4",
+	ServiceTypeElasticsearch:    "startup-4",
+	ServiceTypeOpensearch:       "startup-4",
+	ServiceTypeGrafana:          "startup-1",
+	ServiceTypeInfluxDB:         "startup-4",
+	ServiceTypeRedis:            "startup-4",
+	ServiceTypeMySQL:            "startup-4",
+	ServiceTypeKafka:            "startup-2",
+	ServiceTypeKafkaConnect:     "startup-4",
+	ServiceTypeKafkaMirrormaker: "startup-4",
+	ServiceTypeM3:               "startup-8",
+	ServiceTypeM3Aggregator:     "business-8",
+	ServiceTypeFlink:            "business-4",
+}
+
+// defaultServicePlan returns the minimal plan of a service type, an empty plan is
+// returned for an unknown service type and left for the API to reject
+func defaultServicePlan(serviceType string) string {
+	return defaultServicePlans[serviceType]
+}
+
 func availableServiceTypes() []string {
 	return []string{
 		ServiceTypePG,
@@ -51,19 +115,46 @@ func availableServiceTypes() []string {
 	}
 }
 
+// serviceWaitForReadReplicaSchema is added to the schemas of the service types that can be
+// read replicas, PostgreSQL, MySQL and Redis
+func serviceWaitForReadReplicaSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Waits at creation for the `read_replica` service integration of the service to be active, so that the replica is replicating from its source service before the resources depending on it are created. Only applies to services created with a `read_replica` entry in `service_integrations`.",
+	}
+}
+
 func serviceCommonSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"project": commonSchemaProjectReference,
 
 		"cloud_name": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: cloudNameDiffSuppressFunc,
+			Description:      "Defines where the cloud provider and region where the service is hosted in. This can be changed freely after service is created. Changing the value will trigger a potentially lengthy migration process for the service. Format is cloud provider name (`aws`, `azure`, `do` `google`, `upcloud`, etc.), dash, and the cloud provider specific region name. These are documented on each Cloud provider's own support articles, like [here for Google](https://cloud.google.com/compute/docs/regions-zones/) and [here for AWS](https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/Concepts.RegionsAndAvailabilityZones.html).",
+		},
+		"cloud_geo_region": {
 			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Defines where the cloud provider and region where the service is hosted in. This can be changed freely after service is created. Changing the value will trigger a potentially lengthy migration process for the service. Format is cloud provider name (`aws`, `azure`, `do` `google`, `upcloud`, etc.), dash, and the cloud provider specific region name. These are documented on each Cloud provider's own support articles, like [here for Google](https://cloud.google.com/compute/docs/regions-zones/) and [here for AWS](https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/Concepts.RegionsAndAvailabilityZones.html).",
+			Computed:    true,
+			Description: "Geographical region of the cloud the service runs in, e.g. `europe`.",
+		},
+		"cloud_latitude": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Latitude of the cloud the service runs in.",
+		},
+		"cloud_longitude": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Longitude of the cloud the service runs in.",
 		},
 		"plan": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Defines what kind of computing resources are allocated for the service. It can be changed after creation, though there are some restrictions when going to a smaller plan such as the new plan must have sufficient amount of disk space to store all current data and switching to a plan with fewer nodes might not be supported. The basic plan names are `hobbyist`, `startup-x`, `business-x` and `premium-x` where `x` is (roughly) the amount of memory on each node (also other attributes like number of CPUs and amount of disk space varies but naming is based on memory). The available options can be seem from the [Aiven pricing page](https://aiven.io/pricing).",
+			Computed:    true,
+			Description: "Defines what kind of computing resources are allocated for the service. It can be changed after creation, though there are some restrictions when going to a smaller plan such as the new plan must have sufficient amount of disk space to store all current data and switching to a plan with fewer nodes might not be supported. The basic plan names are `hobbyist`, `startup-x`, `business-x` and `premium-x` where `x` is (roughly) the amount of memory on each node (also other attributes like number of CPUs and amount of disk space varies but naming is based on memory). The available options can be seem from the [Aiven pricing page](https://aiven.io/pricing). When not set a minimal plan of the service type is used, e.g. `startup-4` for PostgreSQL. The minimal plans are a static list of the provider and are not looked up in the plans available to the project.",
 		},
 		"service_name": {
 			Type:        schema.TypeString,
@@ -79,20 +170,32 @@ func serviceCommonSchema() map[string]*schema.Schema {
 		"project_vpc_id": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Specifies the VPC the service should run in. If the value is not set the service is not run inside a VPC. When set, the value should be given as a reference to set up dependencies correctly and the VPC must be in the same cloud and region as the service itself. Project can be freely moved to and from VPC after creation but doing so triggers migration to new servers so the operation can take significant amount of time to complete if the service has a lot of data.",
+			Description: "Specifies the VPC the service should run in. If the value is not set the service is not run inside a VPC, unless `auto_vpc` picks one. When set, the value should be given as a reference to set up dependencies correctly and the VPC must be in the same cloud and region as the service itself. Project can be freely moved to and from VPC after creation but doing so triggers migration to new servers so the operation can take significant amount of time to complete if the service has a lot of data.",
 		},
-		"maintenance_window_dow": {
-			Type:        schema.TypeString,
+		"auto_vpc": {
+			Type:        schema.TypeBool,
 			Optional:    true,
-			Description: "Day of week when maintenance operations should be performed. One monday, tuesday, wednesday, etc.",
+			Description: "Places the service in the VPC of the project in the cloud of the service when `project_vpc_id` is not set, at creation and when the service moves to another cloud. The service is not placed in a VPC when the project has none in the cloud, and the plan fails when it has several. Removing `project_vpc_id` keeps the service in its VPC while `auto_vpc` is enabled.",
+		},
+		"auto_project_vpc_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The VPC the service is placed in by `auto_vpc`, empty when `project_vpc_id` is set",
+		},
+		"maintenance_window_dow": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateMaintenanceWindowDow,
+			Description:  "Day of week when maintenance operations should be performed. One of monday, tuesday, wednesday, thursday, friday, saturday, sunday or never, which disables the maintenance.",
 			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 				return new == ""
 			},
 		},
 		"maintenance_window_time": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateMaintenanceWindowTime,
+			Description:  "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
 			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 				return new == ""
 			},
@@ -102,6 +205,42 @@ func serviceCommonSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Prevents the service from being deleted. It is recommended to set this to `true` for all production services to prevent unintentional service deletion. This does not shield against deleting databases or topics but for services with backups much of the content can at least be restored from backup in case accidental deletion is done.",
 		},
+		"powered": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Runs the service when `true` and powers it off when `false`, e.g. to save costs on non-production services outside business hours. A powered off service keeps its backups but not its data on the nodes, and a service is always created powered on before being powered off. When not set, a new service is powered on and the current power state of an existing service is kept.",
+		},
+		"require_zero_downtime_migration": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Refuses a `cloud_name` change of a single node service, which cannot be migrated to another cloud without downtime. When not set the downtime is only written to the provider log, with `TF_LOG=WARN`, since the plan cannot show warnings.",
+		},
+		"require_production_plan": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Refuses the `hobbyist` plan for a service with `termination_protection` enabled, hobbyist plans have neither high availability nor backups and are not meant for production.",
+		},
+		"promote_read_replica": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Allows the removal of the `read_replica` integration of the service from `service_integrations`, which promotes the read replica to a standalone service for good. The removal fails the plan otherwise.",
+		},
+		"require_unique_name": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Fails the plan of a new service when its name is taken in the project, unless the existing service can be adopted with `adopt_existing`. Otherwise the create itself fails.",
+		},
+		"adopt_existing": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Adopts an existing service of the same name and type when the service is created, e.g. one provisioned by an earlier create that timed out, rather than failing the create. The provider cannot tell which configuration created the existing service, only enable it when no other configuration manages a service of that name.",
+		},
+		"wait_for_component": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Name of a service component, e.g. `kafka_rest`, that must be available before the service is considered ready. Some components only appear after the service is `RUNNING`.",
+		},
 		"service_uri": {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -129,15 +268,38 @@ func serviceCommonSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Username used for connecting to the service, if applicable",
 		},
+		"connection_bundle": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "JSON object with everything needed to connect to the service, e.g. to be written to a Kubernetes Secret at once. Contains `host`, `port`, `user` and `password` and, where present, `dbname`, `sslmode`, the `access_cert` and `access_key` client certificate and the `ca_cert` CA certificate of the project.",
+		},
+		"env_vars": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Connection details of the service as the environment variables commonly read by applications, e.g. to be written to an env file. `PGHOST`, `PGPORT`, `PGUSER`, `PGPASSWORD`, `PGDATABASE`, `PGSSLMODE` and `DATABASE_URL` for PostgreSQL, `MYSQL_HOST`, `MYSQL_PORT`, `MYSQL_USER`, `MYSQL_PASSWORD`, `MYSQL_DATABASE` and `DATABASE_URL` for MySQL, `REDIS_HOST`, `REDIS_PORT`, `REDIS_PASSWORD` and `REDIS_URL` for Redis, `KAFKA_BOOTSTRAP_SERVERS` and, where present, `KAFKA_ACCESS_CERT`, `KAFKA_ACCESS_KEY`, `KAFKA_REST_URL` and `KAFKA_SCHEMA_REGISTRY_URL` for Kafka and `OPENSEARCH_URL` for OpenSearch. Empty for the other service types.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
 		"state": {
 			Type:        schema.TypeString,
 			Computed:    true,
 			Description: "Service state. One of `POWEROFF`, `REBALANCING`, `REBUILDING` or `RUNNING`.",
 		},
+		"node_count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of nodes of the service, as defined by its plan.",
+		},
+		"disk_space_used": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Disk space used by the service as reported by the service metadata, e.g. `1024MiB`.",
+		},
 		"service_integrations": {
 			Type:        schema.TypeList,
 			Optional:    true,
-			Description: "Service integrations to specify when creating a service. Not applied after initial service creation",
+			Description: "Service integrations of the service, e.g. `read_replica`. The integrations added or removed after the service is created are created or deleted, changing the source service of one replaces it. A `read_replica` integration can only be created along with its service, adding one recreates the service, and removing one promotes the service to a standalone service, which must be allowed with `promote_read_replica`.",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"source_service_name": {
@@ -148,7 +310,13 @@ func serviceCommonSchema() map[string]*schema.Schema {
 					"integration_type": {
 						Type:        schema.TypeString,
 						Required:    true,
-						Description: "Type of the service integration. The only supported value at the moment is `read_replica`",
+						Description: "Type of the service integration, e.g. `read_replica` or `kafka_logs`",
+					},
+					"user_config": {
+						Type:        schema.TypeMap,
+						Optional:    true,
+						Description: "User configuration of the service integration, e.g. `kafka_topic` of a `kafka_logs` integration. Only options with scalar values are supported.",
+						Elem:        &schema.Schema{Type: schema.TypeString},
 					},
 				},
 			},
@@ -200,6 +368,49 @@ func serviceCommonSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		"endpoints": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Service component endpoints grouped by network access route, e.g. `public`, `privatelink` or `dynamic`",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"route": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Network access route",
+					},
+					"components": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "Service components reachable through the route",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"component": {
+									Type:        schema.TypeString,
+									Computed:    true,
+									Description: "Service component name",
+								},
+								"host": {
+									Type:        schema.TypeString,
+									Computed:    true,
+									Description: "DNS name for connecting to the service component",
+								},
+								"port": {
+									Type:        schema.TypeInt,
+									Computed:    true,
+									Description: "Port number for connecting to the service component",
+								},
+								"usage": {
+									Type:        schema.TypeString,
+									Computed:    true,
+									Description: "DNS usage name",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -211,14 +422,31 @@ var aivenServiceSchema = map[string]*schema.Schema{
 		ForceNew:    true,
 	},
 	"cloud_name": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Description:      "Cloud the service runs in",
+		DiffSuppressFunc: cloudNameDiffSuppressFunc,
+	},
+	"cloud_geo_region": {
 		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Cloud the service runs in",
+		Computed:    true,
+		Description: "Geographical region of the cloud the service runs in",
+	},
+	"cloud_latitude": {
+		Type:        schema.TypeFloat,
+		Computed:    true,
+		Description: "Latitude of the cloud the service runs in",
+	},
+	"cloud_longitude": {
+		Type:        schema.TypeFloat,
+		Computed:    true,
+		Description: "Longitude of the cloud the service runs in",
 	},
 	"plan": {
 		Type:        schema.TypeString,
 		Optional:    true,
-		Description: "Subscription plan",
+		Computed:    true,
+		Description: "Subscription plan, a minimal plan of the service type is used when not set",
 	},
 	"service_name": {
 		Type:        schema.TypeString,
@@ -239,17 +467,19 @@ var aivenServiceSchema = map[string]*schema.Schema{
 		Description: "Identifier of the VPC the service should be in, if any",
 	},
 	"maintenance_window_dow": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Day of week when maintenance operations should be performed. One monday, tuesday, wednesday, etc.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validateMaintenanceWindowDow,
+		Description:  "Day of week when maintenance operations should be performed. One of monday, tuesday, wednesday, thursday, friday, saturday, sunday or never, which disables the maintenance.",
 		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 			return new == ""
 		},
 	},
 	"maintenance_window_time": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validateMaintenanceWindowTime,
+		Description:  "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
 		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 			return new == ""
 		},
@@ -259,6 +489,42 @@ var aivenServiceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Prevent service from being deleted. It is recommended to have this enabled for all services.",
 	},
+	"powered": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+		Description: "Run the service when true, power it off when false, the current power state is kept when not set",
+	},
+	"require_zero_downtime_migration": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Refuse a cloud change of a single node service",
+	},
+	"require_production_plan": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Refuse the hobbyist plan for a service protected from termination",
+	},
+	"require_unique_name": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Fail the plan of a new service whose name is taken in the project",
+	},
+	"promote_read_replica": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Allow the removal of the read_replica integration, which promotes the read replica",
+	},
+	"adopt_existing": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Adopt an existing service of the same name and type when the service is created",
+	},
+	"wait_for_component": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Service component that must be available before the service is considered ready",
+	},
 	"service_uri": {
 		Type:        schema.TypeString,
 		Computed:    true,
@@ -273,7 +539,7 @@ var aivenServiceSchema = map[string]*schema.Schema{
 	"service_integrations": {
 		Type:        schema.TypeList,
 		Optional:    true,
-		Description: "Service integrations to specify when creating a service. Not applied after initial service creation",
+		Description: "Service integrations of the service, e.g. `read_replica`. The integrations added or removed after the service is created are created or deleted, changing the source service of one replaces it. A `read_replica` integration can only be created along with its service, adding one recreates the service, and removing one promotes the service to a standalone service, which must be allowed with `promote_read_replica`.",
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"source_service_name": {
@@ -284,7 +550,13 @@ var aivenServiceSchema = map[string]*schema.Schema{
 				"integration_type": {
 					Type:        schema.TypeString,
 					Required:    true,
-					Description: "Type of the service integration. The only supported value at the moment is 'read_replica'",
+					Description: "Type of the service integration, e.g. 'read_replica' or 'kafka_logs'",
+				},
+				"user_config": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Description: "User configuration of the service integration",
+					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
 			},
 		},
@@ -336,6 +608,49 @@ var aivenServiceSchema = map[string]*schema.Schema{
 			},
 		},
 	},
+	"endpoints": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Service component endpoints grouped by network access route, e.g. `public`, `privatelink` or `dynamic`",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"route": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Network access route",
+				},
+				"components": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Service components reachable through the route",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"component": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Service component name",
+							},
+							"host": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "DNS name for connecting to the service component",
+							},
+							"port": {
+								Type:        schema.TypeInt,
+								Computed:    true,
+								Description: "Port number for connecting to the service component",
+							},
+							"usage": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "DNS usage name",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
 
 	"service_port": {
 		Type:        schema.TypeInt,
@@ -353,11 +668,39 @@ var aivenServiceSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Description: "Username used for connecting to the service, if applicable",
 	},
+	"connection_bundle": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "JSON object with everything needed to connect to the service",
+	},
+	"env_vars": {
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "Connection details of the service as environment variables, e.g. PGHOST or KAFKA_BOOTSTRAP_SERVERS",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
 	"state": {
 		Type:        schema.TypeString,
 		Computed:    true,
 		Description: "Service state. One of `POWEROFF`, `REBALANCING`, `REBUILDING` and `RUNNING`.",
 	},
+	"node_count": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Number of nodes of the service",
+	},
+	"disk_space_used": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Disk space used by the service as reported by the service metadata, e.g. `1024MiB`.",
+	},
+	"kafka_acl_default": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Default ACL posture of a Kafka service. `allow_all` when the default wildcard ACL exists, `deny` otherwise.",
+	},
 	"cassandra": {
 		Type:        schema.TypeList,
 		Computed:    true,
@@ -398,7 +741,8 @@ var aivenServiceSchema = map[string]*schema.Schema{
 			},
 		},
 	},
-	"opensearch_user_config": generateServiceUserConfiguration(ServiceTypeOpensearch),
+	"opensearch_user_config":    generateServiceUserConfiguration(ServiceTypeOpensearch),
+	"opensearch_index_template": opensearchIndexTemplateSchema(),
 	"grafana": {
 		Type:        schema.TypeList,
 		Computed:    true,
@@ -468,7 +812,10 @@ var aivenServiceSchema = map[string]*schema.Schema{
 			},
 		},
 	},
-	"kafka_user_config": generateServiceUserConfiguration(ServiceTypeKafka),
+	"kafka_user_config":             generateServiceUserConfiguration(ServiceTypeKafka),
+	"kafka_message_max_bytes":       kafkaBrokerSettingsSchema()["kafka_message_max_bytes"],
+	"kafka_replica_fetch_max_bytes": kafkaBrokerSettingsSchema()["kafka_replica_fetch_max_bytes"],
+	"kafka_num_partitions":          kafkaBrokerSettingsSchema()["kafka_num_partitions"],
 	"kafka_connect": {
 		Type:        schema.TypeList,
 		Computed:    true,
@@ -477,13 +824,22 @@ var aivenServiceSchema = map[string]*schema.Schema{
 			Schema: map[string]*schema.Schema{},
 		},
 	},
-	"kafka_connect_user_config": generateServiceUserConfiguration(ServiceTypeKafkaConnect),
+	"kafka_connect_user_config":        generateServiceUserConfiguration(ServiceTypeKafkaConnect),
+	"connect_offset_flush_interval_ms": kafkaConnectWorkerSettingsSchema()["connect_offset_flush_interval_ms"],
+	"consumer_max_poll_records":        kafkaConnectWorkerSettingsSchema()["consumer_max_poll_records"],
 	"mysql": {
 		Type:        schema.TypeList,
 		Computed:    true,
 		Description: "MySQL specific server provided values",
 		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{},
+			Schema: map[string]*schema.Schema{
+				"replica_uri": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "URI of the read replica of the service, the MySQL service with a `read_replica` integration from it",
+					Sensitive:   true,
+				},
+			},
 		},
 	},
 	"mysql_user_config": generateServiceUserConfiguration(ServiceTypeMySQL),
@@ -549,13 +905,26 @@ var aivenServiceSchema = map[string]*schema.Schema{
 			},
 		},
 	},
-	"pg_user_config": generateServiceUserConfiguration(ServiceTypePG),
+	"pg_user_config":                      generateServiceUserConfiguration(ServiceTypePG),
+	"pg_shared_buffers_percentage":        pgMemorySettingsSchema()["pg_shared_buffers_percentage"],
+	"pg_work_mem":                         pgMemorySettingsSchema()["pg_work_mem"],
+	"pgbouncer_min_pool_size":             pgBouncerSettingsSchema()["pgbouncer_min_pool_size"],
+	"pgbouncer_server_reset_query_always": pgBouncerSettingsSchema()["pgbouncer_server_reset_query_always"],
+	"connection_pools":                    pgConnectionPoolsSchema(),
 	"redis": {
 		Type:        schema.TypeList,
 		Computed:    true,
 		Description: "Redis specific server provided values",
 		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{},
+			Schema: map[string]*schema.Schema{
+				"replica_uris": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Redis replica URIs for read-only access, for services with replicas",
+					Sensitive:   true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
 		},
 	},
 	"redis_user_config": generateServiceUserConfiguration(ServiceTypeRedis),
@@ -575,6 +944,25 @@ var aivenServiceSchema = map[string]*schema.Schema{
 						Type: schema.TypeString,
 					},
 				},
+				"jobmanager": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Host and port of the Flink job managers, parsed from host_ports",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"host": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Job manager host",
+							},
+							"port": {
+								Type:        schema.TypeInt,
+								Computed:    true,
+								Description: "Job manager port",
+							},
+						},
+					},
+				},
 			},
 		},
 	},
@@ -592,15 +980,61 @@ func resourceService() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff("service"),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: aivenServiceSchema,
 	}
 }
 
+// adoptExistingService checks that an existing service may be adopted by the service being
+// created: adoption must be enabled with adopt_existing, since the provider cannot tell which
+// configuration created the existing service, and a service of another type cannot be adopted
+func adoptExistingService(client *aiven.Client, project, serviceName, serviceType string, adopt bool) error {
+	if !adopt {
+		return fmt.Errorf("service %s/%s already exists, import it or set adopt_existing to adopt it", project, serviceName)
+	}
+
+	service, err := client.Services.Get(project, serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s/%s already exists but cannot be read: %s", project, serviceName, err)
+	}
+
+	if err := serviceAdoptable(service, serviceType); err != nil {
+		return fmt.Errorf("service %s/%s already exists: %s", project, serviceName, err)
+	}
+
+	log.Printf("[WARN] service %s/%s already exists, adopting it instead of creating it; "+
+		"settings that differ from the configuration are changed on the next apply", project, serviceName)
+
+	return nil
+}
+
+// serviceCreatedPoweredOff tells if a new service is powered off after its creation, which is
+// only the case when powered is set to false; powered is computed so that an unset value keeps
+// the power state of an existing or imported service, it is read from the state of the service
+func serviceCreatedPoweredOff(d *schema.ResourceData) bool {
+	powered, ok := d.GetOkExists("powered")
+	return ok && !powered.(bool)
+}
+
+func serviceAdoptable(service *aiven.Service, serviceType string) error {
+	if service.Type != serviceType {
+		return fmt.Errorf("it is of type %s, not %s", service.Type, serviceType)
+	}
+
+	// a powered off service never becomes RUNNING while waiting for it
+	if service.State == "POWEROFF" {
+		return fmt.Errorf("it is powered off")
+	}
+
+	return nil
+}
+
 func resourceServiceCreateWrapper(serviceType string) schema.CreateContextFunc {
 	if serviceType == "service" {
 		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -660,38 +1094,47 @@ func resourceServiceCreateWrapper(serviceType string) schema.CreateContextFunc {
 }
 
 func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	serviceType := d.Get("service_type").(string)
 	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("service", serviceType, true, d)
-	vpcID := d.Get("project_vpc_id").(string)
-	var apiServiceIntegrations []aiven.NewServiceIntegration
-	tfServiceIntegrations := d.Get("service_integrations")
-	if tfServiceIntegrations != nil {
-		tfServiceIntegrationList := tfServiceIntegrations.([]interface{})
-		for _, definition := range tfServiceIntegrationList {
-			definitionMap := definition.(map[string]interface{})
-			sourceService := definitionMap["source_service_name"].(string)
-			apiIntegration := aiven.NewServiceIntegration{
-				IntegrationType: definitionMap["integration_type"].(string),
-				SourceService:   &sourceService,
-				UserConfig:      make(map[string]interface{}),
-			}
-			apiServiceIntegrations = append(apiServiceIntegrations, apiIntegration)
-		}
+	if serviceType == ServiceTypePG {
+		userConfig = expandPGMemorySettings(d, userConfig)
+		userConfig = expandPGBouncerSettings(d, userConfig)
+	}
+	if serviceType == ServiceTypeOpensearch {
+		userConfig = expandOpensearchIndexTemplate(d, userConfig)
+	}
+	if serviceType == ServiceTypeKafkaConnect {
+		userConfig = expandKafkaConnectWorkerSettings(d, userConfig)
+	}
+	if serviceType == ServiceTypeKafka {
+		userConfig = expandKafkaBrokerSettings(d, userConfig)
+	}
+	apiServiceIntegrations, err := expandServiceIntegrations(d.Get("service_integrations").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	project := d.Get("project").(string)
+	vpcID := serviceProjectVPCID(d)
 	var vpcIDPointer *string
 	if len(vpcID) > 0 {
 		_, vpcID := splitResourceID2(vpcID)
 		vpcIDPointer = &vpcID
 	}
 
-	_, err := client.Services.Create(
+	plan := d.Get("plan").(string)
+	if plan == "" {
+		plan = defaultServicePlan(serviceType)
+		log.Printf("[INFO] no plan given for %s service %s, using the default plan %s",
+			serviceType, d.Get("service_name"), plan)
+	}
+
+	_, err = client.Services.Create(
 		project,
 		aiven.CreateServiceRequest{
-			Cloud:                 d.Get("cloud_name").(string),
+			Cloud:                 normalizeCloudName(d.Get("cloud_name").(string)),
 			MaintenanceWindow:     getMaintenanceWindow(d),
-			Plan:                  d.Get("plan").(string),
+			Plan:                  plan,
 			ProjectVPCID:          vpcIDPointer,
 			ServiceIntegrations:   apiServiceIntegrations,
 			ServiceName:           d.Get("service_name").(string),
@@ -700,7 +1143,13 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 			UserConfig:            userConfig,
 		},
 	)
+	projectServicesCache.forget(project)
 
+	// a create that timed out earlier may have provisioned the service anyway,
+	// it can be adopted so that the apply can be retried
+	if aiven.IsAlreadyExists(err) {
+		err = adoptExistingService(client, project, d.Get("service_name").(string), serviceType, d.Get("adopt_existing").(bool))
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -712,19 +1161,51 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	d.SetId(buildResourceID(d.Get("project").(string), service.Name))
 
-	err = copyServicePropertiesFromAPIResponseToTerraform(d, service, d.Get("project").(string))
+	// a service is always created powered on
+	if serviceCreatedPoweredOff(d) {
+		_, err = client.Services.Update(
+			project,
+			service.Name,
+			aiven.UpdateServiceRequest{
+				Cloud:                 normalizeCloudName(d.Get("cloud_name").(string)),
+				MaintenanceWindow:     getMaintenanceWindow(d),
+				Plan:                  plan,
+				ProjectVPCID:          vpcIDPointer,
+				Powered:               false,
+				TerminationProtection: d.Get("termination_protection").(bool),
+				UserConfig:            userConfig,
+			},
+		)
+		if err != nil {
+			return diag.Errorf("cannot power off service %s: %s", service.Name, err)
+		}
+
+		service, err = resourceServiceWait(ctx, d, m, "update")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = copyServicePropertiesFromAPIResponseToTerraform(d, service, project)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := setServiceProjectProperties(d, client, project, service); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
-func resourceServiceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+func resourceServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
-	service, err := client.Services.Get(projectName, serviceName)
+	var service *aiven.Service
+	err := retryServiceReadOnServerError(ctx, serviceName, func() (err error) {
+		service, err = client.Services.Get(projectName, serviceName)
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(resourceReadHandleNotFound(err, d))
 	}
@@ -733,42 +1214,67 @@ func resourceServiceRead(_ context.Context, d *schema.ResourceData, m interface{
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := setServiceProjectProperties(d, client, projectName, service); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
 func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
-
-	if d.HasChanges("service_integrations") && len(d.Get("service_integrations").([]interface{})) != 0 {
-		return diag.Errorf("service_integrations field can only be set during creation of a service")
-	}
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
 	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("service", d.Get("service_type").(string), false, d)
-	vpcID := d.Get("project_vpc_id").(string)
+	if d.Get("service_type").(string) == ServiceTypePG {
+		userConfig = expandPGMemorySettings(d, userConfig)
+		userConfig = expandPGBouncerSettings(d, userConfig)
+	}
+	if d.Get("service_type").(string) == ServiceTypeOpensearch {
+		userConfig = expandOpensearchIndexTemplate(d, userConfig)
+	}
+	if d.Get("service_type").(string) == ServiceTypeKafkaConnect {
+		userConfig = expandKafkaConnectWorkerSettings(d, userConfig)
+	}
+	if d.Get("service_type").(string) == ServiceTypeKafka {
+		userConfig = expandKafkaBrokerSettings(d, userConfig)
+	}
+	vpcID := serviceProjectVPCID(d)
 	var vpcIDPointer *string
 	if len(vpcID) > 0 {
 		_, vpcID := splitResourceID2(vpcID)
 		vpcIDPointer = &vpcID
 	}
-	_, err := client.Services.Update(
-		projectName,
-		serviceName,
-		aiven.UpdateServiceRequest{
-			Cloud:                 d.Get("cloud_name").(string),
-			MaintenanceWindow:     getMaintenanceWindow(d),
-			Plan:                  d.Get("plan").(string),
-			ProjectVPCID:          vpcIDPointer,
-			Powered:               true,
-			TerminationProtection: d.Get("termination_protection").(bool),
-			UserConfig:            userConfig,
-		},
-	)
-	if err != nil {
+	update := func() error {
+		_, err := client.Services.Update(
+			projectName,
+			serviceName,
+			aiven.UpdateServiceRequest{
+				Cloud:                 normalizeCloudName(d.Get("cloud_name").(string)),
+				MaintenanceWindow:     getMaintenanceWindow(d),
+				Plan:                  d.Get("plan").(string),
+				ProjectVPCID:          vpcIDPointer,
+				Powered:               d.Get("powered").(bool),
+				TerminationProtection: d.Get("termination_protection").(bool),
+				UserConfig:            userConfig,
+			},
+		)
+		return err
+	}
+	settle := func() error {
+		_, err := resourceServiceWait(ctx, d, m, "settle")
+		return err
+	}
+	if err := retryServiceUpdateOnConflict(serviceName, update, settle); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("service_integrations") {
+		if err := updateServiceIntegrations(client, projectName, serviceName, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	service, err := resourceServiceWait(ctx, d, m, "update")
 	if err != nil {
 		return diag.FromErr(err)
@@ -778,28 +1284,76 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := setServiceProjectProperties(d, client, projectName, service); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
-func resourceServiceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+func resourceServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
+	getProject := func() error {
+		_, err := client.Projects.Get(projectName)
+		return err
+	}
 
 	err := client.Services.Delete(projectName, serviceName)
+	projectServicesCache.forget(projectName)
 	if err != nil && !aiven.IsNotFound(err) {
+		if serviceProjectDeleted(err, getProject) {
+			log.Printf("[WARN] project %s is deleted, service %s is deleted with it", projectName, serviceName)
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
-	return nil
-}
+	// the service is torn down after the delete call returns, wait until it is gone so that
+	// the resources depending on it are not deleted or recreated alongside the old service
+	conf := &resource.StateChangeConf{
+		Pending: []string{"DELETING"},
+		Target:  []string{"DELETED"},
+		Refresh: func() (interface{}, string, error) {
+			service, err := client.Services.Get(projectName, serviceName)
+			if err != nil {
+				if aiven.IsNotFound(err) || serviceProjectDeleted(err, getProject) {
+					return struct{}{}, "DELETED", nil
+				}
+				return nil, "", err
+			}
 
-func resourceServiceState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+			log.Printf("[DEBUG] service %s is %s, waiting for it to be deleted", serviceName, service.State)
 
-	if len(strings.Split(d.Id(), "/")) != 2 {
-		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
+			return service, "DELETING", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 2 * time.Second,
+	}
+	if _, err := conf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for Aiven service %s to be deleted: %s", serviceName, err)
+	}
+
+	return nil
+}
+
+// serviceProjectDeleted tells if a service request is forbidden because the project of the
+// service is deleted, which deletes the service too. The project is looked up so that a
+// genuine permission error is not mistaken for a deleted project.
+func serviceProjectDeleted(err error, getProject func() error) bool {
+	if e, ok := err.(aiven.Error); !ok || e.Status != 403 {
+		return false
+	}
+
+	return aiven.IsNotFound(getProject())
+}
+
+func resourceServiceState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*providerMeta).client
+
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
 	}
 
 	projectName, serviceName := splitResourceID2(d.Id())
@@ -816,6 +1370,707 @@ func resourceServiceState(_ context.Context, d *schema.ResourceData, m interface
 	return []*schema.ResourceData{d}, nil
 }
 
+// resourceServiceCustomizeDiff is shared by all the service resources
+func resourceServiceCustomizeDiff(serviceType string) schema.CustomizeDiffFunc {
+	return customdiff.All(
+		customizeDiffServiceProjectChange,
+		customizeDiffServiceNameChange,
+		customizeDiffServiceCloudName,
+		customizeDiffServiceNameUnique(serviceType),
+		customizeDiffServiceIntegrationsUnique,
+		customizeDiffServiceIntegrationsUserConfig,
+		customizeDiffServiceReadReplica,
+		customizeDiffServiceHobbyistTerminationProtection,
+		customizeDiffServiceAutoVPC(serviceType),
+		customizeDiffServiceURIChange,
+		customizeDiffServiceCloudMigrationDowntime,
+	)
+}
+
+// serviceListCache caches the services of the projects for the plan time checks, a plan
+// usually checks many services of the same project; the services of a project are listed
+// again once a service of the project is created or deleted, so that the diffs planned again
+// during an apply see the services created earlier in the apply
+type serviceListCache struct {
+	sync.Mutex
+	services map[string][]*aiven.Service
+}
+
+var projectServicesCache = &serviceListCache{services: make(map[string][]*aiven.Service)}
+
+func (c *serviceListCache) list(client *aiven.Client, project string) ([]*aiven.Service, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if services, ok := c.services[project]; ok {
+		return services, nil
+	}
+
+	services, err := client.Services.List(project)
+	if err != nil {
+		return nil, err
+	}
+	c.services[project] = services
+
+	return services, nil
+}
+
+// forget drops the cached services of a project
+func (c *serviceListCache) forget(project string) {
+	c.Lock()
+	defer c.Unlock()
+
+	delete(c.services, project)
+}
+
+// reset empties the cache, e.g. between tests
+func (c *serviceListCache) reset() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.services = make(map[string][]*aiven.Service)
+}
+
+// customizeDiffServiceNameUnique checks, when require_unique_name is set, that the name of a new
+// service is not taken in the project by a service the create would not adopt, see
+// adoptExistingService; it lists the services of the project at plan time, so it is opt-in
+func customizeDiffServiceNameUnique(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() != "" || !d.Get("require_unique_name").(bool) {
+			return nil
+		}
+
+		meta, ok := m.(*providerMeta)
+		if !ok {
+			return nil
+		}
+		client := meta.client
+
+		// the project or the name may be unknown until apply
+		project := d.Get("project").(string)
+		serviceName := d.Get("service_name").(string)
+		if project == "" || serviceName == "" {
+			return nil
+		}
+
+		t := serviceType
+		if t == "service" {
+			t = d.Get("service_type").(string)
+		}
+
+		services, err := projectServicesCache.list(client, project)
+		if err != nil {
+			log.Printf("[WARN] cannot check that the service name %s is free in project %s: %s", serviceName, project, err)
+			return nil
+		}
+
+		for _, s := range services {
+			if s.Name != serviceName {
+				continue
+			}
+
+			if !d.Get("adopt_existing").(bool) {
+				return fmt.Errorf("service %s already exists in project %s, import it or set adopt_existing to adopt it", serviceName, project)
+			}
+			if err := serviceAdoptable(s, t); err != nil {
+				return fmt.Errorf("service %s already exists in project %s and cannot be adopted: %s", serviceName, project, err)
+			}
+			log.Printf("[WARN] service %s already exists in project %s, it is adopted when created", serviceName, project)
+		}
+
+		return nil
+	}
+}
+
+// customizeDiffServiceProjectChange explains that moving a service to another project recreates
+// it, a service cannot be moved between projects in place; it is an error for a service protected
+// from termination since its deletion would fail only after the plan is applied
+func customizeDiffServiceProjectChange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// the diff is evaluated a second time without state when a replacement is planned
+	if d.Id() == "" || !d.HasChange("project") {
+		return nil
+	}
+
+	oldProject, newProject := d.GetChange("project")
+	msg := fmt.Sprintf("service %s will be destroyed in project %s and recreated in project %s, "+
+		"all the data of the service will be lost unless it is forked from a backup",
+		d.Get("service_name"), oldProject, newProject)
+
+	if protected, _ := d.GetChange("termination_protection"); protected.(bool) {
+		return fmt.Errorf("service %s has termination protection enabled: %s", d.Get("service_name"), msg)
+	}
+
+	log.Printf("[WARN] %s", msg)
+
+	return nil
+}
+
+// customizeDiffServiceNameChange explains that a service cannot be renamed, a new name
+// recreates the service empty; it is an error for a service protected from termination
+// since its deletion would fail only after the plan is applied
+func customizeDiffServiceNameChange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("service_name") {
+		return nil
+	}
+
+	oldName, newName := d.GetChange("service_name")
+	msg := fmt.Sprintf("Aiven services cannot be renamed, changing service_name from %s to %s destroys the "+
+		"service and creates an empty one; to keep the data, create the new service with "+
+		"service_to_fork_from = \"%s\" in its user config and remove the old service once the "+
+		"aiven_service_fork data source reports the fork ready",
+		oldName, newName, oldName)
+
+	if protected, _ := d.GetChange("termination_protection"); protected.(bool) {
+		return fmt.Errorf("service %s has termination protection enabled: %s", oldName, msg)
+	}
+
+	log.Printf("[WARN] %s", msg)
+
+	return nil
+}
+
+// serviceIntegrationsRequiredUserConfig lists the user config options an integration
+// cannot be created without
+var serviceIntegrationsRequiredUserConfig = map[string][]string{
+	"kafka_logs": {"kafka_topic"},
+}
+
+// expandServiceIntegrations converts the service_integrations block to the integrations
+// created together with the service
+func expandServiceIntegrations(tfServiceIntegrations []interface{}) ([]aiven.NewServiceIntegration, error) {
+	var apiServiceIntegrations []aiven.NewServiceIntegration
+	for _, definition := range tfServiceIntegrations {
+		definitionMap := definition.(map[string]interface{})
+		sourceService := definitionMap["source_service_name"].(string)
+		integrationType := definitionMap["integration_type"].(string)
+
+		userConfig, _ := definitionMap["user_config"].(map[string]interface{})
+		apiUserConfig, err := convertServiceIntegrationUserConfig(integrationType, userConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		apiServiceIntegrations = append(apiServiceIntegrations, aiven.NewServiceIntegration{
+			IntegrationType: integrationType,
+			SourceService:   &sourceService,
+			UserConfig:      apiUserConfig,
+		})
+	}
+
+	return apiServiceIntegrations, nil
+}
+
+// hasReadReplicaIntegration tells if the service_integrations entries make the service a read
+// replica
+func hasReadReplicaIntegration(tfServiceIntegrations []interface{}) bool {
+	for _, definition := range tfServiceIntegrations {
+		definitionMap, ok := definition.(map[string]interface{})
+		if ok && definitionMap["integration_type"] == "read_replica" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffServiceIntegrations compares the old and new service_integrations entries by their type
+// and source service, an entry whose source service changes is removed and added again
+func diffServiceIntegrations(old, new []interface{}) (added, removed, changed []map[string]interface{}) {
+	key := func(m map[string]interface{}) string {
+		return m["integration_type"].(string) + "/" + m["source_service_name"].(string)
+	}
+
+	oldByKey := make(map[string]map[string]interface{})
+	for _, raw := range old {
+		m := raw.(map[string]interface{})
+		oldByKey[key(m)] = m
+	}
+
+	newKeys := make(map[string]bool)
+	for _, raw := range new {
+		m := raw.(map[string]interface{})
+		newKeys[key(m)] = true
+
+		o, ok := oldByKey[key(m)]
+		if !ok {
+			added = append(added, m)
+			continue
+		}
+
+		oldUserConfig, _ := o["user_config"].(map[string]interface{})
+		newUserConfig, _ := m["user_config"].(map[string]interface{})
+		if (len(oldUserConfig) != 0 || len(newUserConfig) != 0) && !reflect.DeepEqual(oldUserConfig, newUserConfig) {
+			changed = append(changed, m)
+		}
+	}
+
+	for _, raw := range old {
+		m := raw.(map[string]interface{})
+		if !newKeys[key(m)] {
+			removed = append(removed, m)
+		}
+	}
+
+	return added, removed, changed
+}
+
+// updateServiceIntegrations applies the changes of the service_integrations block to an existing
+// service, the removed integrations are deleted before the added ones are created
+func updateServiceIntegrations(client *aiven.Client, projectName, serviceName string, d *schema.ResourceData) error {
+	o, n := d.GetChange("service_integrations")
+	added, removed, changed := diffServiceIntegrations(o.([]interface{}), n.([]interface{}))
+	if len(added) == 0 && len(removed) == 0 && len(changed) == 0 {
+		return nil
+	}
+
+	integrations, err := client.ServiceIntegrations.List(projectName, serviceName)
+	if err != nil {
+		return fmt.Errorf("cannot list integrations of %s/%s: %s", projectName, serviceName, err)
+	}
+
+	find := func(m map[string]interface{}) *aiven.ServiceIntegration {
+		for _, i := range integrations {
+			if i.SourceService == nil || i.DestinationService == nil {
+				continue
+			}
+			if i.IntegrationType == m["integration_type"].(string) &&
+				*i.SourceService == m["source_service_name"].(string) &&
+				*i.DestinationService == serviceName {
+				return i
+			}
+		}
+		return nil
+	}
+
+	var toCreate []interface{}
+	for _, m := range added {
+		toCreate = append(toCreate, m)
+	}
+
+	for _, m := range removed {
+		i := find(m)
+		if i == nil {
+			continue
+		}
+		if err := client.ServiceIntegrations.Delete(projectName, i.ServiceIntegrationID); err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot delete %s integration from %s: %s", i.IntegrationType, *i.SourceService, err)
+		}
+	}
+
+	for _, m := range changed {
+		i := find(m)
+		if i == nil {
+			toCreate = append(toCreate, m)
+			continue
+		}
+
+		userConfig, _ := m["user_config"].(map[string]interface{})
+		apiUserConfig, err := convertServiceIntegrationUserConfig(i.IntegrationType, userConfig)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.ServiceIntegrations.Update(projectName, i.ServiceIntegrationID,
+			aiven.UpdateServiceIntegrationRequest{UserConfig: apiUserConfig})
+		if err != nil {
+			return fmt.Errorf("cannot update %s integration from %s: %s", i.IntegrationType, *i.SourceService, err)
+		}
+	}
+
+	newIntegrations, err := expandServiceIntegrations(toCreate)
+	if err != nil {
+		return err
+	}
+	for _, i := range newIntegrations {
+		_, err := client.ServiceIntegrations.Create(projectName, aiven.CreateServiceIntegrationRequest{
+			DestinationService: &serviceName,
+			IntegrationType:    i.IntegrationType,
+			SourceService:      i.SourceService,
+			UserConfig:         i.UserConfig,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot create %s integration from %s: %s", i.IntegrationType, *i.SourceService, err)
+		}
+	}
+
+	return nil
+}
+
+// convertServiceIntegrationUserConfig converts the flat user config of a service_integrations entry
+// to the API format, it is validated against the user config schema of the integration type
+func convertServiceIntegrationUserConfig(integrationType string, userConfig map[string]interface{}) (map[string]interface{}, error) {
+	definitionRaw, ok := templates.GetUserConfigSchema("integration")[integrationType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported integration type %s", integrationType)
+	}
+	properties, _ := definitionRaw.(map[string]interface{})["properties"].(map[string]interface{})
+
+	apiConfig := make(map[string]interface{})
+	for key, value := range userConfig {
+		definition, ok := properties[key].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s integration has no user config option %s", integrationType, key)
+		}
+
+		var converted interface{}
+		var err error
+		switch getAivenSchemaType(definition["type"]) {
+		case "integer":
+			converted, err = convertTerraformUserConfigValueToAPICompatibleFormatInteger(value)
+		case "number":
+			converted, err = convertTerraformUserConfigValueToAPICompatibleFormatNumber(value)
+		case "boolean":
+			converted, err = convertTerraformUserConfigValueToAPICompatibleFormatBoolean(value)
+		case "string":
+			converted, err = convertTerraformUserConfigValueToAPICompatibleFormatString(value)
+		default:
+			err = fmt.Errorf("only options with scalar values are supported")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s integration user config option %s: %s", integrationType, key, err)
+		}
+
+		apiConfig[key] = converted
+	}
+
+	for _, key := range serviceIntegrationsRequiredUserConfig[integrationType] {
+		if _, ok := apiConfig[key]; !ok {
+			return nil, fmt.Errorf("%s integration requires user config option %s", integrationType, key)
+		}
+	}
+
+	return apiConfig, nil
+}
+
+// customizeDiffServiceIntegrationsUnique rejects a service_integrations block listing the same
+// integration more than once, the API fails on duplicates only after the plan is applied
+func customizeDiffServiceIntegrationsUnique(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	seen := make(map[string]int)
+	for i, raw := range d.Get("service_integrations").([]interface{}) {
+		integration, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s", integration["source_service_name"], integration["integration_type"])
+		if j, ok := seen[key]; ok {
+			return fmt.Errorf("service_integrations.%d duplicates service_integrations.%d: "+
+				"integration of type %s from service %s is listed more than once",
+				i, j, integration["integration_type"], integration["source_service_name"])
+		}
+		seen[key] = i
+	}
+
+	return nil
+}
+
+// customizeDiffServiceReadReplica surfaces the read_replica integration changes of an existing
+// service in the plan: the API creates a read replica along with its service only, so adding the
+// integration recreates the service, and deleting the integration promotes the replica to a
+// standalone service for good, so removing it must be allowed with promote_read_replica
+func customizeDiffServiceReadReplica(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("service_integrations") {
+		return nil
+	}
+
+	o, n := d.GetChange("service_integrations")
+	added, removed, _ := diffServiceIntegrations(o.([]interface{}), n.([]interface{}))
+
+	for _, m := range removed {
+		if m["integration_type"] == "read_replica" && !d.Get("promote_read_replica").(bool) {
+			return fmt.Errorf("removing the read_replica integration from %s promotes service %s to a standalone "+
+				"service, which cannot be undone; set promote_read_replica to allow it", m["source_service_name"], d.Get("service_name"))
+		}
+	}
+
+	for _, m := range added {
+		if m["integration_type"] == "read_replica" {
+			return d.ForceNew("service_integrations")
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffServiceIntegrationsUserConfig validates the user config of the service_integrations
+// entries against the user config schema of their integration type
+func customizeDiffServiceIntegrationsUserConfig(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for i, raw := range d.Get("service_integrations").([]interface{}) {
+		integration, ok := raw.(map[string]interface{})
+		if !ok || !d.NewValueKnown(fmt.Sprintf("service_integrations.%d.user_config", i)) {
+			continue
+		}
+
+		userConfig, _ := integration["user_config"].(map[string]interface{})
+		if _, err := convertServiceIntegrationUserConfig(integration["integration_type"].(string), userConfig); err != nil {
+			return fmt.Errorf("service_integrations.%d: %s", i, err)
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffServiceHobbyistTerminationProtection checks, when require_production_plan is set,
+// that a service protected from termination is not on a hobbyist plan, termination protection
+// implies a production service while hobbyist plans have neither high availability nor backups
+func customizeDiffServiceHobbyistTerminationProtection(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.Get("require_production_plan").(bool) {
+		return nil
+	}
+	if d.Get("plan").(string) != "hobbyist" || !d.Get("termination_protection").(bool) {
+		return nil
+	}
+
+	return fmt.Errorf("service %s has termination protection enabled on a hobbyist plan, hobbyist plans have "+
+		"no high availability and no backups and are not meant for production, unset require_production_plan "+
+		"to keep the plan", d.Get("service_name"))
+}
+
+// customizeDiffServiceCloudName fails the plan when the normalized cloud name is not in the
+// clouds catalog of the project, a misspelled cloud would only fail when the plan is applied;
+// the catalog is only looked up for a new cloud, and the check is skipped when it cannot be
+// listed
+func customizeDiffServiceCloudName(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !serviceCloudChange(d) {
+		return nil
+	}
+
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return nil
+	}
+	client := meta.client
+
+	// the project or the cloud may be unknown until apply
+	project := d.Get("project").(string)
+	cloudName := d.Get("cloud_name").(string)
+	if project == "" || cloudName == "" {
+		return nil
+	}
+
+	cloud, err := getCloud(client, project, normalizeCloudName(cloudName))
+	if err != nil {
+		log.Printf("[WARN] cannot check that the cloud %s is available in project %s: %s", cloudName, project, err)
+		return nil
+	}
+	if cloud == nil {
+		return fmt.Errorf("cloud %s (%s) is not available in project %s", cloudName, normalizeCloudName(cloudName), project)
+	}
+
+	return nil
+}
+
+// customizeDiffServiceCloudMigrationDowntime checks a cloud change of a single node service, which
+// is unavailable while its only node is replaced in the new cloud
+func customizeDiffServiceCloudMigrationDowntime(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !serviceCloudChange(d) || d.Get("node_count").(int) != 1 {
+		return nil
+	}
+
+	oldCloud, newCloud := d.GetChange("cloud_name")
+	if d.Get("require_zero_downtime_migration").(bool) {
+		return fmt.Errorf("service %s has a single node and cannot be moved from %s to %s without downtime, "+
+			"unset require_zero_downtime_migration to accept the downtime", d.Get("service_name"), oldCloud, newCloud)
+	}
+
+	log.Printf("[WARN] service %s has a single node, moving it from %s to %s causes downtime",
+		d.Get("service_name"), oldCloud, newCloud)
+
+	return nil
+}
+
+// customizeDiffServiceURIChange marks the connection details of the service unknown until
+// apply when the service moves to another cloud or VPC, which can give it a new URI, so that
+// the resources depending on them are planned to be updated
+func customizeDiffServiceURIChange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || (!serviceCloudChange(d) && !d.HasChange("project_vpc_id") && !d.HasChange("auto_project_vpc_id")) {
+		return nil
+	}
+
+	for _, k := range []string{"service_uri", "service_host", "service_port"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// autoVPCApplies tells if the VPC of the service is picked with auto_vpc, which is the case
+// when no VPC is configured, at creation and when the service moves to another cloud; a
+// configured VPC is kept even when it is unchanged by a cloud change
+func autoVPCApplies(id string, autoVPC, vpcSet, cloudChange bool) bool {
+	if !autoVPC || vpcSet {
+		return false
+	}
+
+	return id == "" || cloudChange
+}
+
+// customizeDiffServiceAutoVPC plans the VPC the service is placed in with auto_vpc as its
+// auto_project_vpc_id, so that the plan shows the VPC and an ambiguous choice fails the plan
+// rather than the apply; the deprecated aiven_service has no auto_vpc
+func customizeDiffServiceAutoVPC(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if serviceType == "service" {
+			return nil
+		}
+
+		autoVPC := d.Get("auto_vpc").(bool)
+		vpcSet := d.Get("project_vpc_id").(string) != "" || !d.NewValueKnown("project_vpc_id")
+
+		// the picked VPC is dropped once a VPC is configured or auto_vpc is disabled
+		if !autoVPC || vpcSet {
+			if d.Get("auto_project_vpc_id").(string) == "" {
+				return nil
+			}
+			return d.SetNew("auto_project_vpc_id", "")
+		}
+
+		// removing project_vpc_id keeps the service in the VPC it was configured in
+		if oldVPCID, _ := d.GetChange("project_vpc_id"); oldVPCID.(string) != "" {
+			return d.SetNew("auto_project_vpc_id", oldVPCID)
+		}
+
+		if !autoVPCApplies(d.Id(), autoVPC, vpcSet, serviceCloudChange(d)) {
+			return nil
+		}
+
+		meta, ok := m.(*providerMeta)
+		if !ok {
+			return nil
+		}
+		client := meta.client
+
+		// the project may be unknown until the apply, which plans the service again
+		project := d.Get("project").(string)
+		if project == "" || !d.NewValueKnown("cloud_name") {
+			return d.SetNewComputed("auto_project_vpc_id")
+		}
+
+		// no cloud means the default one, whose VPC cannot be told
+		cloudName := d.Get("cloud_name").(string)
+		if cloudName == "" {
+			return nil
+		}
+
+		vpcID, err := lookupProjectVPCID(client, project, cloudName)
+		if err != nil {
+			return err
+		}
+
+		return d.SetNew("auto_project_vpc_id", vpcID)
+	}
+}
+
+// serviceProjectVPCID returns the VPC of the service as a project/VPC ID reference, the
+// configured one or else the one picked with auto_vpc; aiven_service has no auto_vpc
+func serviceProjectVPCID(d *schema.ResourceData) string {
+	if vpcID := d.Get("project_vpc_id").(string); vpcID != "" {
+		return vpcID
+	}
+
+	vpcID, _ := d.Get("auto_project_vpc_id").(string)
+	return vpcID
+}
+
+// lookupProjectVPCID returns the project/VPC ID reference of the VPC of the project in the
+// cloud, empty when there is none
+func lookupProjectVPCID(client *aiven.Client, project, cloudName string) (string, error) {
+	vpcs, err := client.VPCs.List(project)
+	if err != nil {
+		return "", fmt.Errorf("cannot list the VPCs of project %s: %s", project, err)
+	}
+
+	vpc, err := selectProjectVPC(vpcs, cloudName)
+	if err != nil {
+		return "", fmt.Errorf("cannot pick a VPC for auto_vpc in project %s: %s", project, err)
+	}
+	if vpc == nil {
+		return "", nil
+	}
+
+	return buildResourceID(project, vpc.ProjectVPCID), nil
+}
+
+// selectProjectVPC returns the VPC in the cloud, nil when there is none and an error when
+// there are several to choose from, VPCs being deleted are ignored
+func selectProjectVPC(vpcs []*aiven.VPC, cloudName string) (*aiven.VPC, error) {
+	var matches []*aiven.VPC
+	for _, vpc := range vpcs {
+		if vpc.State == "DELETING" || vpc.State == "DELETED" {
+			continue
+		}
+		if normalizeCloudName(vpc.CloudName) == normalizeCloudName(cloudName) {
+			matches = append(matches, vpc)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	default:
+		var ids []string
+		for _, vpc := range matches {
+			ids = append(ids, vpc.ProjectVPCID)
+		}
+		return nil, fmt.Errorf("%d VPCs in %s (%s), set project_vpc_id to choose one",
+			len(matches), cloudName, strings.Join(ids, ", "))
+	}
+}
+
+// retryServiceUpdateOnConflict runs the update and, when the API rejects it with a conflict
+// because the service is being changed concurrently, waits for the service to settle and
+// retries the update once
+func retryServiceUpdateOnConflict(serviceName string, update func() error, settle func() error) error {
+	err := update()
+	if e, ok := err.(aiven.Error); !ok || e.Status != 409 {
+		return err
+	}
+
+	log.Printf("[WARN] service %s is being changed concurrently, retrying the update once it is RUNNING: %s", serviceName, err)
+	if err := settle(); err != nil {
+		return err
+	}
+
+	return update()
+}
+
+// serviceReadRetries bounds the retries of a service read failing with a server error, the
+// backoff between them starts at serviceReadBackoff and doubles up to serviceReadMaxBackoff
+const (
+	serviceReadRetries    = 4
+	serviceReadMaxBackoff = 8 * time.Second
+)
+
+var serviceReadBackoff = time.Second
+
+// retryServiceReadOnServerError runs the read and retries it with an exponential backoff
+// while the API answers with a transient server error, other errors are returned as is
+func retryServiceReadOnServerError(ctx context.Context, serviceName string, read func() error) error {
+	backoff := serviceReadBackoff
+	for retry := 0; ; retry++ {
+		err := read()
+		if e, ok := err.(aiven.Error); !ok || e.Status < 500 || retry == serviceReadRetries {
+			return err
+		}
+
+		log.Printf("[WARN] cannot read service %s, retrying in %s: %s", serviceName, backoff, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > serviceReadMaxBackoff {
+			backoff = serviceReadMaxBackoff
+		}
+	}
+}
+
 func resourceServiceWait(ctx context.Context, d *schema.ResourceData, m interface{}, operation string) (*aiven.Service, error) {
 	var timeout time.Duration
 	if operation == "create" {
@@ -825,20 +2080,145 @@ func resourceServiceWait(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 
 	w := &ServiceChangeWaiter{
-		Client:      m.(*aiven.Client),
-		Operation:   operation,
-		Project:     d.Get("project").(string),
-		ServiceName: d.Get("service_name").(string),
+		Client:             m.(*providerMeta).client,
+		Operation:          operation,
+		Project:            d.Get("project").(string),
+		ServiceName:        d.Get("service_name").(string),
+		StateChangeWebhook: m.(*providerMeta).stateChangeWebhook,
+		WaitForComponent:   d.Get("wait_for_component").(string),
 	}
 
-	service, err := w.Conf(timeout).WaitForStateContext(ctx)
+	// only the service types that can have read replicas have wait_for_read_replica
+	if waitForReadReplica, _ := d.Get("wait_for_read_replica").(bool); operation == "create" && waitForReadReplica {
+		w.WaitForReadReplica = hasReadReplicaIntegration(d.Get("service_integrations").([]interface{}))
+	}
+
+	// a service is created powered on, it is only powered off by an update, and a concurrent
+	// change can leave it either way
+	targets := []string{aivenTargetState}
+	switch {
+	case operation == "settle":
+		targets = []string{aivenTargetState, aivenPoweredOffState}
+	case operation != "create" && !d.Get("powered").(bool):
+		targets = []string{aivenPoweredOffState}
+	}
+
+	// a plan change can be applied before a cloud migration completes, when both are
+	// changed at once wait for the service to reflect both of them
+	if operation == "update" && d.HasChange("plan") && d.HasChange("cloud_name") {
+		w.Plan = d.Get("plan").(string)
+		w.CloudName = normalizeCloudName(d.Get("cloud_name").(string))
+	}
+
+	// the VPC of a service moving to another cloud changes as part of the migration, when
+	// both are changed at once wait for the service to be in the new cloud and VPC
+	if operation == "update" && d.HasChange("cloud_name") &&
+		(d.HasChange("project_vpc_id") || d.HasChange("auto_project_vpc_id")) {
+		vpcID := serviceProjectVPCID(d)
+		if vpcID != "" {
+			_, vpcID = splitResourceID2(vpcID)
+		}
+
+		w.CloudName = normalizeCloudName(d.Get("cloud_name").(string))
+		w.ProjectVPCID = &vpcID
+	}
+
+	service, err := w.Conf(timeout, targets...).WaitForStateContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for Aiven service to be RUNNING: %s", err)
+		return nil, w.waitError(err)
 	}
 
 	return service.(*aiven.Service), nil
 }
 
+// cloudProviderAliases maps the commonly used cloud provider names to the ones used by Aiven
+var cloudProviderAliases = map[string]string{
+	"amazon":       "aws",
+	"az":           "azure",
+	"digitalocean": "do",
+	"gce":          "google",
+	"gcp":          "google",
+}
+
+// normalizeCloudName converts a cloud name using a provider alias or a different
+// spelling to the canonical Aiven cloud name, e.g. `GCP_europe_west1` to `google-europe-west1`
+func normalizeCloudName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "-")
+
+	parts := strings.SplitN(name, "-", 2)
+	if provider, ok := cloudProviderAliases[parts[0]]; ok && len(parts) == 2 {
+		return provider + "-" + parts[1]
+	}
+
+	return name
+}
+
+// cloudNameDiffSuppressFunc suppresses a diff between a cloud name alias and its canonical name
+func cloudNameDiffSuppressFunc(_, old, new string, _ *schema.ResourceData) bool {
+	return old != "" && normalizeCloudName(old) == normalizeCloudName(new)
+}
+
+// serviceCloudChange tells whether a plan moves the service to another cloud, CustomizeDiff
+// runs before cloudNameDiffSuppressFunc so an alias of the current cloud is a change there
+func serviceCloudChange(d *schema.ResourceDiff) bool {
+	oldCloud, newCloud := d.GetChange("cloud_name")
+
+	return normalizeCloudName(oldCloud.(string)) != normalizeCloudName(newCloud.(string))
+}
+
+// clouds caches the clouds catalog per project, it does not change during a Terraform run
+var clouds = struct {
+	sync.Mutex
+	byProject map[string][]*aiven.Cloud
+}{byProject: make(map[string][]*aiven.Cloud)}
+
+// resetClouds empties the clouds cache, e.g. between tests
+func resetClouds() {
+	clouds.Lock()
+	defer clouds.Unlock()
+
+	clouds.byProject = make(map[string][]*aiven.Cloud)
+}
+
+// getCloud looks a cloud up in the clouds catalog available to a project, nil is returned
+// for a cloud missing from the catalog
+func getCloud(client *aiven.Client, project, cloudName string) (*aiven.Cloud, error) {
+	clouds.Lock()
+	defer clouds.Unlock()
+
+	list, ok := clouds.byProject[project]
+	if !ok {
+		var err error
+		if list, err = client.Clouds.List(project); err != nil {
+			return nil, err
+		}
+		clouds.byProject[project] = list
+	}
+
+	for _, c := range list {
+		if c.CloudName == cloudName {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func setServiceCloudGeo(d *schema.ResourceData, cloud *aiven.Cloud) error {
+	if cloud == nil {
+		cloud = &aiven.Cloud{}
+	}
+
+	if err := d.Set("cloud_geo_region", cloud.GeoRegion); err != nil {
+		return err
+	}
+	if err := d.Set("cloud_latitude", cloud.GeoLatitude); err != nil {
+		return err
+	}
+
+	return d.Set("cloud_longitude", cloud.GeoLongitude)
+}
+
 func getMaintenanceWindow(d *schema.ResourceData) *aiven.MaintenanceWindow {
 	dow := d.Get("maintenance_window_dow").(string)
 	t := d.Get("maintenance_window_time").(string)
@@ -867,6 +2247,9 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 	if err := d.Set("state", service.State); err != nil {
 		return err
 	}
+	if err := d.Set("powered", service.State != "POWEROFF"); err != nil {
+		return err
+	}
 	if err := d.Set("plan", service.Plan); err != nil {
 		return err
 	}
@@ -889,8 +2272,18 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 		return err
 	}
 
+	// the VPC picked with auto_vpc is kept apart from the configured one, an unset
+	// project_vpc_id is not planned to move the service out of the VPC
+	vpcKey := "project_vpc_id"
+	if autoVPC, _ := d.Get("auto_vpc").(bool); autoVPC && d.Get("project_vpc_id").(string) == "" {
+		vpcKey = "auto_project_vpc_id"
+	}
 	if service.ProjectVPCID != nil {
-		if err := d.Set("project_vpc_id", buildResourceID(project, *service.ProjectVPCID)); err != nil {
+		if err := d.Set(vpcKey, buildResourceID(project, *service.ProjectVPCID)); err != nil {
+			return err
+		}
+	} else if vpcKey == "auto_project_vpc_id" {
+		if err := d.Set(vpcKey, ""); err != nil {
 			return err
 		}
 	}
@@ -902,11 +2295,10 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 	}
 
 	params := service.URIParams
-	if err := d.Set("service_host", params["host"]); err != nil {
+	host, port := serviceHostPort(service)
+	if err := d.Set("service_host", host); err != nil {
 		return err
 	}
-
-	port, _ := strconv.ParseInt(params["port"], 10, 32)
 	if err := d.Set("service_port", port); err != nil {
 		return err
 	}
@@ -927,8 +2319,252 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 	if err := d.Set("components", flattenServiceComponents(service)); err != nil {
 		return fmt.Errorf("cannot set `components` : %s", err)
 	}
+	if err := d.Set("endpoints", flattenServiceEndpoints(service)); err != nil {
+		return fmt.Errorf("cannot set `endpoints` : %s", err)
+	}
 
-	return copyConnectionInfoFromAPIResponseToTerraform(d, serviceType, service.ConnectionInfo)
+	if err := d.Set("node_count", service.NodeCount); err != nil {
+		return err
+	}
+	if err := d.Set("disk_space_used", serviceDiskSpaceUsed(service)); err != nil {
+		return err
+	}
+
+	if err := d.Set("env_vars", serviceEnvVars(service)); err != nil {
+		return err
+	}
+
+	if serviceType == ServiceTypePG {
+		if err := flattenPGMemorySettings(d, service.UserConfig); err != nil {
+			return err
+		}
+		if err := flattenPGBouncerSettings(d, service.UserConfig); err != nil {
+			return err
+		}
+		if err := d.Set("connection_pools", flattenPGConnectionPools(service.ConnectionPools)); err != nil {
+			return err
+		}
+	}
+
+	if serviceType == ServiceTypeOpensearch {
+		if err := d.Set("opensearch_index_template", flattenOpensearchIndexTemplate(service.UserConfig)); err != nil {
+			return err
+		}
+	}
+
+	if serviceType == ServiceTypeKafkaConnect {
+		if err := flattenKafkaConnectWorkerSettings(d, service.UserConfig); err != nil {
+			return err
+		}
+	}
+
+	if serviceType == ServiceTypeKafka {
+		if err := d.Set("kafka_acl_default", kafkaACLDefault(service)); err != nil {
+			return err
+		}
+		if err := flattenKafkaBrokerSettings(d, service.UserConfig); err != nil {
+			return err
+		}
+	}
+
+	return copyConnectionInfoFromAPIResponseToTerraform(d, serviceType, service)
+}
+
+// serviceHostPort returns the host and port of a service from its URI parameters, falling back to
+// parsing the service URI; IPv6 hosts are returned without brackets
+func serviceHostPort(r *aiven.Service) (string, int64) {
+	host, port := r.URIParams["host"], r.URIParams["port"]
+
+	if host == "" && r.URI != "" {
+		if strings.Contains(r.URI, "://") {
+			if u, err := url.Parse(r.URI); err == nil {
+				host, port = u.Hostname(), u.Port()
+			}
+		} else if h, p, err := net.SplitHostPort(r.URI); err == nil {
+			host, port = h, p
+		}
+	}
+
+	p, _ := strconv.ParseInt(port, 10, 32)
+
+	return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"), p
+}
+
+// serviceDiskSpaceUsed reads the disk usage from the service metadata, numeric values
+// are reported in MiB
+func serviceDiskSpaceUsed(r *aiven.Service) string {
+	metadata, ok := r.Metadata.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	switch v := metadata["disk_space_used"].(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.0fMiB", v)
+	}
+
+	return ""
+}
+
+// userConfigShorthand reads a top-level shorthand of a user config key that is to be sent to the
+// API: one set when the service is created, or one changed since. The shorthands are Optional and
+// Computed, a shorthand removed from the configuration keeps the value read back from the user
+// config, which must not override a change made to the nested key itself
+func userConfigShorthand(d *schema.ResourceData, field string) (interface{}, bool) {
+	if d.IsNewResource() {
+		return d.GetOk(field)
+	}
+	if d.HasChange(field) {
+		return d.Get(field), true
+	}
+
+	return nil, false
+}
+
+// setServiceProjectProperties sets the properties of a service that also depend on its project,
+// its CA certificate, its cloud and the other services of the project
+func setServiceProjectProperties(d *schema.ResourceData, client *aiven.Client, project string, service *aiven.Service) error {
+	if service.Type == ServiceTypeMySQL {
+		if err := setMySQLReplicaURI(d, client, project, service); err != nil {
+			return err
+		}
+	}
+
+	// the CA certificate is left out of the connection bundle rather than failing the refresh
+	ca, err := getProjectCACert(client, project)
+	if err != nil {
+		log.Printf("[WARN] cannot get the CA certificate of project %s: %s", project, err)
+	}
+
+	bundle, err := serviceConnectionBundle(service, ca)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("connection_bundle", bundle); err != nil {
+		return err
+	}
+
+	// the geo fields are left unset rather than failing the refresh
+	cloud, err := getCloud(client, project, service.CloudName)
+	if err != nil {
+		log.Printf("[WARN] cannot get cloud %s of project %s: %s", service.CloudName, project, err)
+		return nil
+	}
+
+	return setServiceCloudGeo(d, cloud)
+}
+
+// projectCACerts caches the CA certificates of the projects, they do not change during a Terraform run
+var projectCACerts = struct {
+	sync.Mutex
+	byProject map[string]string
+}{byProject: make(map[string]string)}
+
+func getProjectCACert(client *aiven.Client, project string) (string, error) {
+	projectCACerts.Lock()
+	defer projectCACerts.Unlock()
+
+	if ca, ok := projectCACerts.byProject[project]; ok {
+		return ca, nil
+	}
+
+	ca, err := client.CA.Get(project)
+	if err != nil {
+		return "", err
+	}
+	projectCACerts.byProject[project] = ca
+
+	return ca, nil
+}
+
+// serviceConnectionBundle assembles the connection details of a service and the CA certificate
+// of its project into a single JSON object
+func serviceConnectionBundle(r *aiven.Service, ca string) (string, error) {
+	bundle := make(map[string]string)
+	for _, k := range []string{"host", "port", "user", "password", "dbname", "sslmode"} {
+		if v := r.URIParams[k]; v != "" {
+			bundle[k] = v
+		}
+	}
+
+	if r.Type == ServiceTypeKafka {
+		if r.ConnectionInfo.KafkaAccessCert != "" {
+			bundle["access_cert"] = r.ConnectionInfo.KafkaAccessCert
+		}
+		if r.ConnectionInfo.KafkaAccessKey != "" {
+			bundle["access_key"] = r.ConnectionInfo.KafkaAccessKey
+		}
+	}
+
+	if ca != "" {
+		bundle["ca_cert"] = ca
+	}
+
+	b, err := json.Marshal(bundle)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal connection bundle: %s", err)
+	}
+
+	return string(b), nil
+}
+
+// serviceEnvVarParams maps the environment variables of a service type to the connection
+// parameters of the service they are read from
+var serviceEnvVarParams = map[string]map[string]string{
+	ServiceTypePG: {
+		"PGHOST":     "host",
+		"PGPORT":     "port",
+		"PGUSER":     "user",
+		"PGPASSWORD": "password",
+		"PGDATABASE": "dbname",
+		"PGSSLMODE":  "sslmode",
+	},
+	ServiceTypeMySQL: {
+		"MYSQL_HOST":     "host",
+		"MYSQL_PORT":     "port",
+		"MYSQL_USER":     "user",
+		"MYSQL_PASSWORD": "password",
+		"MYSQL_DATABASE": "dbname",
+	},
+	ServiceTypeRedis: {
+		"REDIS_HOST":     "host",
+		"REDIS_PORT":     "port",
+		"REDIS_PASSWORD": "password",
+	},
+}
+
+// serviceEnvVars assembles the connection details of a service into the environment
+// variables applications of its type commonly read, variables without a value are left out
+func serviceEnvVars(r *aiven.Service) map[string]string {
+	env := make(map[string]string)
+	for name, param := range serviceEnvVarParams[r.Type] {
+		env[name] = r.URIParams[param]
+	}
+
+	switch r.Type {
+	case ServiceTypePG, ServiceTypeMySQL:
+		env["DATABASE_URL"] = r.URI
+	case ServiceTypeRedis:
+		env["REDIS_URL"] = r.URI
+	case ServiceTypeKafka:
+		env["KAFKA_BOOTSTRAP_SERVERS"] = r.URI
+		env["KAFKA_ACCESS_CERT"] = r.ConnectionInfo.KafkaAccessCert
+		env["KAFKA_ACCESS_KEY"] = r.ConnectionInfo.KafkaAccessKey
+		env["KAFKA_REST_URL"] = r.ConnectionInfo.KafkaRestURI
+		env["KAFKA_SCHEMA_REGISTRY_URL"] = r.ConnectionInfo.SchemaRegistryURI
+	case ServiceTypeOpensearch:
+		env["OPENSEARCH_URL"] = r.URI
+	}
+
+	for name, v := range env {
+		if v == "" {
+			delete(env, name)
+		}
+	}
+
+	return env
 }
 
 func flattenServiceComponents(r *aiven.Service) []map[string]interface{} {
@@ -941,6 +2577,11 @@ func flattenServiceComponents(r *aiven.Service) []map[string]interface{} {
 			"port":      c.Port,
 			"route":     c.Route,
 			"usage":     c.Usage,
+			"ssl":       serviceComponentSSL(c),
+		}
+		// only listed for the components it applies to, e.g. the kafka component
+		if c.KafkaAuthenticationMethod != "" {
+			component["kafka_authentication_method"] = c.KafkaAuthenticationMethod
 		}
 		components = append(components, component)
 	}
@@ -948,11 +2589,115 @@ func flattenServiceComponents(r *aiven.Service) []map[string]interface{} {
 	return components
 }
 
+// serviceComponentSSL tells if the endpoint of a component is encrypted, endpoints are
+// encrypted unless the component reports otherwise, only the components that can disable
+// encryption report it
+func serviceComponentSSL(c *aiven.ServiceComponents) bool {
+	if c.Ssl == nil {
+		return true
+	}
+
+	return *c.Ssl
+}
+
+// flattenServiceEndpoints groups the service components by their network access route,
+// routes are sorted by name to keep the list stable between reads
+func flattenServiceEndpoints(r *aiven.Service) []map[string]interface{} {
+	byRoute := make(map[string][]map[string]interface{})
+	var routes []string
+
+	for _, c := range r.Components {
+		if _, ok := byRoute[c.Route]; !ok {
+			routes = append(routes, c.Route)
+		}
+		byRoute[c.Route] = append(byRoute[c.Route], map[string]interface{}{
+			"component": c.Component,
+			"host":      c.Host,
+			"port":      c.Port,
+			"usage":     c.Usage,
+		})
+	}
+	sort.Strings(routes)
+
+	var endpoints []map[string]interface{}
+	for _, route := range routes {
+		endpoints = append(endpoints, map[string]interface{}{
+			"route":      route,
+			"components": byRoute[route],
+		})
+	}
+
+	return endpoints
+}
+
+// flattenFlinkHostPorts splits the host:port pairs of the Flink job managers, IPv6
+// hosts are bracketed and malformed pairs are skipped
+func flattenFlinkHostPorts(hostPorts []string) []map[string]interface{} {
+	var jobManagers []map[string]interface{}
+	for _, hp := range hostPorts {
+		host, p, err := net.SplitHostPort(hp)
+		if err != nil {
+			log.Printf("[WARN] cannot parse Flink host_ports value %q: %s", hp, err)
+			continue
+		}
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			log.Printf("[WARN] cannot parse Flink host_ports value %q: %s", hp, err)
+			continue
+		}
+
+		jobManagers = append(jobManagers, map[string]interface{}{
+			"host": host,
+			"port": port,
+		})
+	}
+
+	return jobManagers
+}
+
+// mysqlReadReplica finds the read replica of a MySQL service, the service with a read_replica
+// integration from it; the API reports no replica URI for MySQL services themselves
+func mysqlReadReplica(r *aiven.Service) string {
+	for _, i := range r.Integrations {
+		if i.IntegrationType != "read_replica" || i.SourceService == nil || i.DestinationService == nil {
+			continue
+		}
+		if *i.SourceService == r.Name {
+			return *i.DestinationService
+		}
+	}
+
+	return ""
+}
+
+// setMySQLReplicaURI sets the replica URI of a MySQL service from the connection info of its
+// read replica service, the replica is only looked up when the service has a read_replica
+// integration
+func setMySQLReplicaURI(d *schema.ResourceData, client *aiven.Client, project string, r *aiven.Service) error {
+	var uri string
+	if replicaName := mysqlReadReplica(r); replicaName != "" {
+		replica, err := client.Services.Get(project, replicaName)
+		switch {
+		case aiven.IsNotFound(err):
+			// the replica is being deleted or is already gone while its integration remains
+		case err != nil:
+			return fmt.Errorf("cannot get read replica %s of service %s/%s: %w", replicaName, project, r.Name, err)
+		case len(replica.ConnectionInfo.MySQLURIs) > 0:
+			uri = replica.ConnectionInfo.MySQLURIs[0]
+		default:
+			uri = replica.URI
+		}
+	}
+
+	return d.Set(ServiceTypeMySQL, []map[string]interface{}{{"replica_uri": uri}})
+}
+
 func copyConnectionInfoFromAPIResponseToTerraform(
 	d *schema.ResourceData,
 	serviceType string,
-	connectionInfo aiven.ConnectionInfo,
+	service *aiven.Service,
 ) error {
+	connectionInfo := service.ConnectionInfo
 	props := make(map[string]interface{})
 
 	switch serviceType {
@@ -990,8 +2735,10 @@ func copyConnectionInfoFromAPIResponseToTerraform(
 		}
 		props["replica_uri"] = connectionInfo.PostgresReplicaURI
 	case "redis":
+		props["replica_uris"] = connectionInfo.RedisSlaveURIs
 	case "flink":
 		props["host_ports"] = connectionInfo.FlinkHostPorts
+		props["jobmanager"] = flattenFlinkHostPorts(connectionInfo.FlinkHostPorts)
 	case "kafka_mirrormaker":
 	case "m3db":
 	case "m3aggregator":
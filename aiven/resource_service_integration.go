@@ -176,7 +176,7 @@ func plainEndpointID(fullEndpointID *string) *string {
 
 func resourceServiceIntegrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var integration *aiven.ServiceIntegration
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName := d.Get("project").(string)
 	integrationType := d.Get("integration_type").(string)
 	sourceServiceName := d.Get("source_service_name").(string)
@@ -266,7 +266,7 @@ func resourceServiceIntegrationCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceServiceIntegrationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	integration, err := client.ServiceIntegrations.Get(projectName, integrationID)
@@ -287,7 +287,7 @@ func resourceServiceIntegrationRead(_ context.Context, d *schema.ResourceData, m
 }
 
 func resourceServiceIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	integrationType := d.Get("integration_type").(string)
@@ -308,7 +308,7 @@ func resourceServiceIntegrationUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceServiceIntegrationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	err := client.ServiceIntegrations.Delete(projectName, integrationID)
@@ -320,7 +320,7 @@ func resourceServiceIntegrationDelete(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceServiceIntegrationState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<integration_id>", d.Id())
@@ -165,7 +165,7 @@ func resourceServiceIntegrationEndpoint() *schema.Resource {
 }
 
 func resourceServiceIntegrationEndpointCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName := d.Get("project").(string)
 	endpointType := d.Get("endpoint_type").(string)
 	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("endpoint", endpointType, true, d)
@@ -188,7 +188,7 @@ func resourceServiceIntegrationEndpointCreate(ctx context.Context, d *schema.Res
 }
 
 func resourceServiceIntegrationEndpointRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, endpointID := splitResourceID2(d.Id())
 	endpoint, err := client.ServiceIntegrationEndpoints.Get(projectName, endpointID)
@@ -205,7 +205,7 @@ func resourceServiceIntegrationEndpointRead(_ context.Context, d *schema.Resourc
 }
 
 func resourceServiceIntegrationEndpointUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, endpointID := splitResourceID2(d.Id())
 	endpointType := d.Get("endpoint_type").(string)
@@ -225,7 +225,7 @@ func resourceServiceIntegrationEndpointUpdate(ctx context.Context, d *schema.Res
 }
 
 func resourceServiceIntegrationEndpointDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, endpointID := splitResourceID2(d.Id())
 	err := client.ServiceIntegrationEndpoints.Delete(projectName, endpointID)
@@ -237,7 +237,7 @@ func resourceServiceIntegrationEndpointDelete(_ context.Context, d *schema.Resou
 }
 
 func resourceServiceIntegrationEndpointState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<endpoint_id>", d.Id())
@@ -89,7 +89,7 @@ func testAccServiceIntegrationEndpointResource(name string) string {
 }
 
 func testAccCheckAivenServiceIntegraitonEndpointResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each aiven_service_integration_endpoint is destroyed
 	for _, rs := range s.RootModule().Resources {
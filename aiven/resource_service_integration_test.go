@@ -293,7 +293,7 @@ func testAccServiceIntegrationShouldFailResource() string {
 }
 
 func testAccCheckAivenServiceIntegrationResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each aiven_service_integration is destroyed
 	for _, rs := range s.RootModule().Resources {
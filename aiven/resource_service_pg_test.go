@@ -3,6 +3,7 @@ package aiven
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -280,6 +281,170 @@ func testAccPGReadReplicaServiceResource(name string) string {
 		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
 }
 
+func TestAccAivenService_pgReadReplicaSourceChange(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPGReadReplicaSourceResource(rName, "first", false),
+				Check:  testAccCheckAivenServiceReadReplicaSource("aiven_pg.replica", fmt.Sprintf("test-acc-sr-first-%s", rName)),
+			},
+			{
+				// the replica of the first service would be promoted
+				Config:      testAccPGReadReplicaSourceResource(rName, "second", false),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("set promote_read_replica to allow it"),
+			},
+			{
+				// the replica is recreated from the second service
+				Config: testAccPGReadReplicaSourceResource(rName, "second", true),
+				Check:  testAccCheckAivenServiceReadReplicaSource("aiven_pg.replica", fmt.Sprintf("test-acc-sr-second-%s", rName)),
+			},
+		},
+	})
+}
+
+func testAccPGReadReplicaSourceResource(name, source string, promote bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "first" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-first-%s"
+		}
+
+		resource "aiven_pg" "second" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-second-%s"
+		}
+
+		resource "aiven_pg" "replica" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-replica-%s"
+			promote_read_replica = %t
+
+			service_integrations {
+				integration_type = "read_replica"
+				source_service_name = aiven_pg.%s.service_name
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, promote, source)
+}
+
+// testAccCheckAivenServiceReadReplicaSource checks that the only read_replica integration of
+// the service is from the given source service
+func testAccCheckAivenServiceReadReplicaSource(n, source string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		projectName, serviceName := splitResourceID2(rs.Primary.ID)
+		c := testAccProvider.Meta().(*providerMeta).client
+		integrations, err := c.ServiceIntegrations.List(projectName, serviceName)
+		if err != nil {
+			return err
+		}
+
+		var sources []string
+		for _, i := range integrations {
+			if i.IntegrationType == "read_replica" && i.DestinationService != nil && *i.DestinationService == serviceName {
+				sources = append(sources, *i.SourceService)
+			}
+		}
+		if len(sources) != 1 || sources[0] != source {
+			return fmt.Errorf("service %s has read replica integrations from %v, want %s", serviceName, sources, source)
+		}
+
+		return nil
+	}
+}
+
+func TestAccAivenService_pgReadReplicaWait(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the check runs right after the create returns, before any later refresh
+				Config: testAccPGReadReplicaWaitResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_pg.replica", "wait_for_read_replica", "true"),
+					testAccCheckAivenServiceReadReplicaActive("aiven_pg.replica"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPGReadReplicaWaitResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "source" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-source-%s"
+		}
+
+		resource "aiven_pg" "replica" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-replica-%s"
+			wait_for_read_replica = true
+
+			service_integrations {
+				integration_type = "read_replica"
+				source_service_name = aiven_pg.source.service_name
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
+
+// testAccCheckAivenServiceReadReplicaActive checks that the read_replica integration of the
+// service is active
+func testAccCheckAivenServiceReadReplicaActive(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		projectName, serviceName := splitResourceID2(rs.Primary.ID)
+		c := testAccProvider.Meta().(*providerMeta).client
+		service, err := c.Services.Get(projectName, serviceName)
+		if err != nil {
+			return err
+		}
+
+		if !readReplicaActive(service) {
+			return fmt.Errorf("read_replica integration of service %s is not active", serviceName)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAivenServiceTerminationProtection(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r := s.RootModule().Resources[n]
@@ -287,7 +452,7 @@ func testAccCheckAivenServiceTerminationProtection(n string) resource.TestCheckF
 
 		projectName, serviceName := splitResourceID2(a["id"])
 
-		c := testAccProvider.Meta().(*aiven.Client)
+		c := testAccProvider.Meta().(*providerMeta).client
 
 		service, err := c.Services.Get(projectName, serviceName)
 		if err != nil {
@@ -111,7 +111,7 @@ func resourceServiceUser() *schema.Resource {
 }
 
 func resourceServiceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -150,7 +150,7 @@ func resourceServiceUserCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceServiceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, username := splitResourceID3(d.Id())
 
@@ -210,7 +210,7 @@ func copyServiceUserPropertiesFromAPIResponseToTerraform(
 }
 
 func resourceServiceUserRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, username := splitResourceID3(d.Id())
 	user, err := client.ServiceUsers.Get(projectName, serviceName, username)
@@ -227,7 +227,7 @@ func resourceServiceUserRead(_ context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceServiceUserDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, username := splitResourceID3(d.Id())
 	err := client.ServiceUsers.Delete(projectName, serviceName, username)
@@ -239,7 +239,7 @@ func resourceServiceUserDelete(_ context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceServiceUserState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 3 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>/<username>", d.Id())
@@ -0,0 +1,128 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// serviceAPI talks to the REST APIs served by the services themselves, e.g. OpenSearch,
+// OpenSearch Dashboards or Grafana, they are not a part of the Aiven API and are reached
+// through the URIs of the service with its admin credentials, so unlike the Aiven API the
+// service must be reachable from where Terraform runs
+type serviceAPI struct {
+	uri      string
+	username string
+	password string
+	client   *http.Client
+}
+
+// getServiceOfType gets a service and makes sure it is of the type the service API is for
+func getServiceOfType(client *aiven.Client, project, serviceName, serviceType string) (*aiven.Service, error) {
+	service, err := client.Services.Get(project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if service.Type != serviceType {
+		return nil, fmt.Errorf("service %s/%s is of type %s, only %s services are supported",
+			project, serviceName, service.Type, serviceType)
+	}
+
+	return service, nil
+}
+
+// serviceAPITimeout bounds every request to the API of a service, the waits polling it are
+// bounded by the timeouts of the resources
+const serviceAPITimeout = time.Minute
+
+// newProjectServiceAPI talks to the API of a service at the URI with the admin credentials of
+// the service, trusting the CA of the project; the CA is left out rather than failing when it
+// cannot be read, the services with a publicly trusted certificate do not need it
+func newProjectServiceAPI(client *aiven.Client, project string, service *aiven.Service, uri string) (*serviceAPI, error) {
+	ca, err := getProjectCACert(client, project)
+	if err != nil {
+		log.Printf("[WARN] cannot get the CA certificate of project %s: %s", project, err)
+	}
+
+	return newServiceAPI(uri, service.URIParams["user"], service.URIParams["password"], ca)
+}
+
+// newServiceAPI uses the credentials embedded in the URI when there are any,
+// the given ones otherwise; the CA certificate, when given, is trusted in addition
+// to the system ones
+func newServiceAPI(uri, username, password, caCert string) (*serviceAPI, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse service URI: %s", err)
+	}
+
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+		u.User = nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caCert != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, fmt.Errorf("cannot parse the CA certificate of the project")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &serviceAPI{
+		uri:      strings.TrimSuffix(u.String(), "/"),
+		username: username,
+		password: password,
+		client:   &http.Client{Timeout: serviceAPITimeout, Transport: transport},
+	}, nil
+}
+
+func (o *serviceAPI) do(ctx context.Context, method, path, contentType string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, o.uri+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.SetBasicAuth(o.username, o.password)
+	// required by the OpenSearch Dashboards API, ignored by the other APIs
+	req.Header.Set("osd-xsrf", "true")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reach the service at %s, it must be reachable from where Terraform runs: %s", o.uri, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, aiven.Error{Message: string(b), Status: resp.StatusCode}
+	}
+
+	return b, nil
+}
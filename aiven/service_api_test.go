@@ -0,0 +1,151 @@
+package aiven
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+func Test_serviceAPIDo(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantStatus int
+	}{
+		{
+			"ok",
+			http.StatusOK,
+			`{"acknowledged":true}`,
+			0,
+		},
+		{
+			"not found",
+			http.StatusNotFound,
+			`{"error":"index_not_found_exception"}`,
+			http.StatusNotFound,
+		},
+		{
+			"server error",
+			http.StatusInternalServerError,
+			"internal error",
+			http.StatusInternalServerError,
+		},
+		{
+			"redirect",
+			http.StatusNotModified,
+			"",
+			http.StatusNotModified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if user, password, ok := r.BasicAuth(); !ok || user != "avnadmin" || password != "secret" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				if r.URL.Path != "/_test" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			api, err := newServiceAPI(srv.URL+"/", "avnadmin", "secret", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := api.do(context.Background(), http.MethodGet, "/_test", "", nil)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("do() error = %v", err)
+				}
+				if string(b) != tt.body {
+					t.Errorf("do() = %s, want %s", b, tt.body)
+				}
+				return
+			}
+
+			e, ok := err.(aiven.Error)
+			if !ok {
+				t.Fatalf("do() error = %v, want an aiven.Error", err)
+			}
+			if e.Status != tt.wantStatus || e.Message != tt.body {
+				t.Errorf("do() error = %+v, want status %d and message %q", e, tt.wantStatus, tt.body)
+			}
+			if aiven.IsNotFound(err) != (tt.wantStatus == http.StatusNotFound) {
+				t.Errorf("aiven.IsNotFound(%v) = %v", err, aiven.IsNotFound(err))
+			}
+		})
+	}
+}
+
+func Test_serviceAPIUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	api, err := newServiceAPI(srv.URL, "avnadmin", "secret", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = api.do(context.Background(), http.MethodGet, "/", "", nil)
+	if err == nil || !strings.Contains(err.Error(), "must be reachable from where Terraform runs") {
+		t.Errorf("do() error = %v, want the service to be unreachable", err)
+	}
+	if _, ok := err.(aiven.Error); ok {
+		t.Errorf("do() error = %v, a connection error is not an aiven.Error", err)
+	}
+}
+
+func Test_newServiceAPIProjectCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	tests := []struct {
+		name    string
+		caCert  string
+		wantErr bool
+	}{
+		{
+			"project CA",
+			ca,
+			false,
+		},
+		{
+			"system CAs only",
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, err := newServiceAPI(srv.URL, "avnadmin", "secret", tt.caCert)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = api.do(context.Background(), http.MethodGet, "/", "", nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := newServiceAPI(srv.URL, "avnadmin", "secret", "not a certificate"); err == nil {
+		t.Error("newServiceAPI() with an invalid CA certificate should fail")
+	}
+}
@@ -3,9 +3,14 @@
 package aiven
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
@@ -19,6 +24,28 @@ type ServiceChangeWaiter struct {
 	Operation   string
 	Project     string
 	ServiceName string
+
+	// StateChangeWebhook is an optional URL notified on every observed state transition
+	StateChangeWebhook string
+
+	// Plan and CloudName, when set, must be reflected by the service before the wait ends
+	Plan      string
+	CloudName string
+	// ProjectVPCID, when set, is the VPC ID the service must be in before the wait ends, empty
+	// for a service leaving its VPC
+	ProjectVPCID *string
+
+	// WaitForComponent, when set, is a component that must be listed by the service before the wait ends
+	WaitForComponent string
+	// WaitForReadReplica, when set, requires the read_replica integration the service is the
+	// destination of to be active before the wait ends
+	WaitForReadReplica bool
+
+	// targets are the states that end the wait, set up by Conf
+	targets   []string
+	lastState string
+	// waitingFor describes why the latest refreshed service was not considered ready
+	waitingFor string
 }
 
 const (
@@ -26,6 +53,7 @@ const (
 	aivenPendingState          = "REBUILDING"
 	aivenRebalancingState      = "REBALANCING"
 	aivenServicesStartingState = "WAITING_FOR_SERVICES"
+	aivenPoweredOffState       = "POWEROFF"
 )
 
 // RefreshFunc will call the Aiven client and refresh its state.
@@ -40,25 +68,208 @@ func (w *ServiceChangeWaiter) RefreshFunc() resource.StateRefreshFunc {
 			return nil, "", err
 		}
 
-		state := service.State
-		if w.Operation == "update" {
-			// When updating service don't wait for it to enter RUNNING state because that can take
-			// very long time if for example service plan or cloud it runs in is changed and the
-			// service has a lot of data. If the service was already previously in RUNNING state we
-			// can manage the associated resources even if the service is rebuilding.
-			state = aivenTargetState
+		return service, w.serviceState(service), nil
+	}
+}
+
+// serviceState maps a refreshed service to the state the waiter is working with
+func (w *ServiceChangeWaiter) serviceState(service *aiven.Service) string {
+	w.observeState(service.State)
+
+	// a powered off service has no components to be ready, when it is the only target the
+	// service is waited for even on update
+	if w.isTarget(aivenPoweredOffState) {
+		w.waitingFor = ""
+		if service.State == aivenPoweredOffState {
+			return aivenPoweredOffState
 		}
+		if !w.isTarget(aivenTargetState) {
+			w.waitingFor = fmt.Sprintf("service is %s, waiting for it to be powered off", service.State)
+			return aivenPendingState
+		}
+	}
+
+	state := service.State
+	if w.Operation == "update" {
+		// When updating service don't wait for it to enter RUNNING state because that can take
+		// very long time if for example service plan or cloud it runs in is changed and the
+		// service has a lot of data. If the service was already previously in RUNNING state we
+		// can manage the associated resources even if the service is rebuilding.
+		state = aivenTargetState
+	}
+
+	w.waitingFor = ""
+	switch {
+	case state != aivenTargetState:
+		w.waitingFor = fmt.Sprintf("service is %s", state)
+	case !w.changesApplied(service):
+		state = aivenPendingState
+		w.waitingFor = "waiting for " + strings.Join(w.pendingChanges(service), ", ")
+	case !backupsReady(service):
+		state = aivenServicesStartingState
+		w.waitingFor = "waiting for the first backup of the service"
+	case !grafanaReady(service):
+		state = aivenServicesStartingState
+		w.waitingFor = "waiting for Grafana to be reachable"
+	case !componentReady(service, w.WaitForComponent):
+		state = aivenServicesStartingState
+		w.waitingFor = fmt.Sprintf("waiting for component %s", w.WaitForComponent)
+	case w.WaitForReadReplica && !readReplicaActive(service):
+		state = aivenServicesStartingState
+		w.waitingFor = "waiting for the read_replica integration to be active"
+	}
+
+	return state
+}
+
+// waitError describes a failed wait together with the reason the service was last seen not ready
+func (w *ServiceChangeWaiter) waitError(err error) error {
+	targets := strings.Join(w.targetStates(), " or ")
+	if w.waitingFor == "" {
+		return fmt.Errorf("error waiting for Aiven service to be %s: %s", targets, err)
+	}
+
+	return fmt.Errorf("error waiting for Aiven service to be %s: %s; %s", targets, err, w.waitingFor)
+}
+
+// targetStates returns the states that end the wait, RUNNING unless set up otherwise
+func (w *ServiceChangeWaiter) targetStates() []string {
+	if len(w.targets) == 0 {
+		return []string{aivenTargetState}
+	}
+
+	return w.targets
+}
 
-		if state == aivenTargetState && !backupsReady(service) {
-			state = aivenServicesStartingState
+func (w *ServiceChangeWaiter) isTarget(state string) bool {
+	for _, t := range w.targetStates() {
+		if t == state {
+			return true
 		}
+	}
+
+	return false
+}
+
+// observeState notifies the state change webhook when the service state differs from
+// the previously observed one
+func (w *ServiceChangeWaiter) observeState(state string) {
+	oldState := w.lastState
+	w.lastState = state
+
+	if w.StateChangeWebhook == "" || oldState == "" || oldState == state {
+		return
+	}
+
+	if err := w.notifyStateChange(oldState, state); err != nil {
+		log.Printf("[WARN] cannot notify state change webhook about service %s transition from %s to %s: %s",
+			w.ServiceName, oldState, state, err)
+	}
+}
+
+func (w *ServiceChangeWaiter) notifyStateChange(oldState, newState string) error {
+	body, err := json.Marshal(map[string]string{
+		"project":   w.Project,
+		"service":   w.ServiceName,
+		"old_state": oldState,
+		"new_state": newState,
+	})
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(w.StateChangeWebhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-		if state == aivenTargetState && !grafanaReady(service) {
-			state = aivenServicesStartingState
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// changesApplied checks that the requested plan, cloud and VPC are all reflected by the
+// service, so that an update changing several of them does not return after only one of them
+// is applied
+func (w *ServiceChangeWaiter) changesApplied(service *aiven.Service) bool {
+	pending := w.pendingChanges(service)
+	if len(pending) > 0 {
+		log.Printf("[INFO] service %s is waiting for %s", w.ServiceName, strings.Join(pending, ", "))
+		return false
+	}
+
+	return true
+}
+
+// pendingChanges describes the requested changes that the service does not reflect yet
+func (w *ServiceChangeWaiter) pendingChanges(service *aiven.Service) []string {
+	var pending []string
+	if w.Plan != "" && service.Plan != w.Plan {
+		pending = append(pending, fmt.Sprintf("plan %s (currently %s)", w.Plan, service.Plan))
+	}
+
+	if w.CloudName != "" && service.CloudName != w.CloudName {
+		pending = append(pending, fmt.Sprintf("cloud %s (currently %s)", w.CloudName, service.CloudName))
+	}
+
+	if w.ProjectVPCID != nil {
+		var vpcID string
+		if service.ProjectVPCID != nil {
+			vpcID = *service.ProjectVPCID
 		}
+		if vpcID != *w.ProjectVPCID {
+			pending = append(pending, fmt.Sprintf("VPC %s (currently %s)", vpcDescription(*w.ProjectVPCID), vpcDescription(vpcID)))
+		}
+	}
 
-		return service, state, nil
+	return pending
+}
+
+func vpcDescription(vpcID string) string {
+	if vpcID == "" {
+		return "none"
 	}
+
+	return vpcID
+}
+
+// componentReady checks if the service lists the given component, components like `kafka_rest`
+// can appear only after the service is RUNNING
+func componentReady(service *aiven.Service, component string) bool {
+	if component == "" {
+		return true
+	}
+
+	for _, c := range service.Components {
+		if c.Component == component {
+			return true
+		}
+	}
+
+	log.Printf("[DEBUG] service component `%s` is not yet available", component)
+
+	return false
+}
+
+// readReplicaActive checks if the read_replica integration the service is the destination of
+// is active, the integration is listed as inactive until the replica replicates from its source
+func readReplicaActive(service *aiven.Service) bool {
+	for _, i := range service.Integrations {
+		if i.IntegrationType == "read_replica" && i.DestinationService != nil && *i.DestinationService == service.Name {
+			if !i.Active {
+				log.Printf("[DEBUG] read_replica integration %s is not yet active", i.ServiceIntegrationID)
+			}
+			return i.Active
+		}
+	}
+
+	log.Printf("[DEBUG] read_replica integration of service `%s` is not yet listed", service.Name)
+
+	return false
 }
 
 func grafanaReady(service *aiven.Service) bool {
@@ -126,13 +337,17 @@ func backupsReady(service *aiven.Service) bool {
 	return len(service.Backups) > 0
 }
 
-// Conf sets up the configuration to refresh.
-func (w *ServiceChangeWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
+// Conf sets up the configuration to refresh, the wait ends when the service reaches one of
+// the target states, RUNNING when none is given. POWEROFF is a target when the service is
+// meant to be powered off.
+func (w *ServiceChangeWaiter) Conf(timeout time.Duration, targets ...string) *resource.StateChangeConf {
 	log.Printf("[DEBUG] Service waiter timeout %.0f minutes", timeout.Minutes())
 
+	w.targets = targets
+
 	return &resource.StateChangeConf{
 		Pending:                   []string{aivenPendingState, aivenRebalancingState, aivenServicesStartingState},
-		Target:                    []string{aivenTargetState},
+		Target:                    w.targetStates(),
 		Refresh:                   w.RefreshFunc(),
 		Delay:                     10 * time.Second,
 		Timeout:                   timeout,
@@ -0,0 +1,358 @@
+package aiven
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_observeState(t *testing.T) {
+	var mu sync.Mutex
+	var got []map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("cannot decode webhook payload: %s", err)
+		}
+
+		mu.Lock()
+		got = append(got, payload)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	w := &ServiceChangeWaiter{
+		Project:            "test-project",
+		ServiceName:        "test-service",
+		StateChangeWebhook: srv.URL,
+	}
+
+	for _, state := range []string{"REBUILDING", "REBUILDING", "RUNNING", "RUNNING"} {
+		w.observeState(state)
+	}
+
+	want := []map[string]string{
+		{
+			"project":   "test-project",
+			"service":   "test-service",
+			"old_state": "REBUILDING",
+			"new_state": "RUNNING",
+		},
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("observeState() webhook calls = %v, want %v", got, want)
+	}
+}
+
+func Test_observeStateWebhookFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	w := &ServiceChangeWaiter{
+		Project:            "test-project",
+		ServiceName:        "test-service",
+		StateChangeWebhook: srv.URL,
+	}
+
+	// webhook failures are not fatal for the waiter
+	w.observeState("REBUILDING")
+	w.observeState("RUNNING")
+
+	if w.lastState != "RUNNING" {
+		t.Errorf("observeState() last state = %s, want RUNNING", w.lastState)
+	}
+}
+
+func Test_changesApplied(t *testing.T) {
+	vpcGCP, vpcAWS, noVPC := "vpc-gcp", "vpc-aws", ""
+
+	tests := []struct {
+		name    string
+		w       *ServiceChangeWaiter
+		service *aiven.Service
+		want    bool
+	}{
+		{
+			"only plan changed",
+			&ServiceChangeWaiter{Plan: "business-4", CloudName: "google-europe-west1"},
+			&aiven.Service{Plan: "business-4", CloudName: "aws-eu-west-1"},
+			false,
+		},
+		{
+			"only cloud changed",
+			&ServiceChangeWaiter{Plan: "business-4", CloudName: "google-europe-west1"},
+			&aiven.Service{Plan: "startup-4", CloudName: "google-europe-west1"},
+			false,
+		},
+		{
+			"plan and cloud changed",
+			&ServiceChangeWaiter{Plan: "business-4", CloudName: "google-europe-west1"},
+			&aiven.Service{Plan: "business-4", CloudName: "google-europe-west1"},
+			true,
+		},
+		{
+			"only cloud changed with VPC",
+			&ServiceChangeWaiter{CloudName: "google-europe-west1", ProjectVPCID: &vpcGCP},
+			&aiven.Service{CloudName: "google-europe-west1", ProjectVPCID: &vpcAWS},
+			false,
+		},
+		{
+			"cloud and VPC changed",
+			&ServiceChangeWaiter{CloudName: "google-europe-west1", ProjectVPCID: &vpcGCP},
+			&aiven.Service{CloudName: "google-europe-west1", ProjectVPCID: &vpcGCP},
+			true,
+		},
+		{
+			"left VPC",
+			&ServiceChangeWaiter{CloudName: "google-europe-west1", ProjectVPCID: &noVPC},
+			&aiven.Service{CloudName: "google-europe-west1"},
+			true,
+		},
+		{
+			"nothing awaited",
+			&ServiceChangeWaiter{},
+			&aiven.Service{Plan: "startup-4", CloudName: "aws-eu-west-1"},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.w.changesApplied(tt.service); got != tt.want {
+				t.Errorf("changesApplied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_serviceStatePendingChanges(t *testing.T) {
+	vpcGCP, vpcAWS := "vpc-gcp", "vpc-aws"
+
+	w := &ServiceChangeWaiter{
+		Operation:    "update",
+		ServiceName:  "test-service",
+		CloudName:    "google-europe-west1",
+		ProjectVPCID: &vpcGCP,
+	}
+
+	// the cloud is migrated first, the service is then moved to the new VPC
+	services := []*aiven.Service{
+		{State: "REBUILDING", CloudName: "aws-eu-west-1", ProjectVPCID: &vpcAWS},
+		{State: "REBUILDING", CloudName: "google-europe-west1", ProjectVPCID: &vpcAWS},
+		{State: "RUNNING", CloudName: "google-europe-west1", ProjectVPCID: &vpcGCP},
+	}
+	want := []struct {
+		state      string
+		waitingFor string
+	}{
+		{"REBUILDING", "waiting for cloud google-europe-west1 (currently aws-eu-west-1), VPC vpc-gcp (currently vpc-aws)"},
+		{"REBUILDING", "waiting for VPC vpc-gcp (currently vpc-aws)"},
+		{"RUNNING", ""},
+	}
+
+	for i, service := range services {
+		if got := w.serviceState(service); got != want[i].state || w.waitingFor != want[i].waitingFor {
+			t.Errorf("serviceState() poll %d = %s, %q, want %s, %q", i, got, w.waitingFor, want[i].state, want[i].waitingFor)
+		}
+	}
+}
+
+func Test_serviceStateWaitForReadReplica(t *testing.T) {
+	source, replica := "test-source", "test-replica"
+	running := func(integrations ...*aiven.ServiceIntegration) *aiven.Service {
+		return &aiven.Service{Name: replica, Type: "pg", State: "RUNNING", Integrations: integrations}
+	}
+	readReplica := func(active bool) *aiven.ServiceIntegration {
+		return &aiven.ServiceIntegration{
+			ServiceIntegrationID: "read-replica-id",
+			IntegrationType:      "read_replica",
+			SourceService:        &source,
+			DestinationService:   &replica,
+			Active:               active,
+		}
+	}
+
+	// the integration is listed inactive while the replica catches up with its source
+	polls := []*aiven.Service{
+		running(),
+		running(readReplica(false)),
+		running(readReplica(false)),
+		running(readReplica(true)),
+	}
+
+	w := &ServiceChangeWaiter{
+		Operation:          "create",
+		WaitForReadReplica: true,
+	}
+
+	var count int
+	conf := &resource.StateChangeConf{
+		Pending: []string{aivenPendingState, aivenRebalancingState, aivenServicesStartingState},
+		Target:  []string{aivenTargetState},
+		Refresh: func() (interface{}, string, error) {
+			service := polls[count]
+			if count < len(polls)-1 {
+				count++
+			}
+			return service, w.serviceState(service), nil
+		},
+		Timeout:      time.Minute,
+		PollInterval: time.Millisecond,
+	}
+
+	got, err := conf.WaitForState()
+	if err != nil {
+		t.Fatalf("WaitForState() error = %v", err)
+	}
+
+	if count != 3 {
+		t.Errorf("WaitForState() returned after %d polls, want the fourth one", count+1)
+	}
+
+	if !readReplicaActive(got.(*aiven.Service)) {
+		t.Errorf("WaitForState() returned a service with an inactive read_replica integration")
+	}
+}
+
+func Test_serviceStateWaitForComponent(t *testing.T) {
+	running := func(components ...string) *aiven.Service {
+		s := &aiven.Service{Type: "kafka", State: "RUNNING"}
+		for _, c := range components {
+			s.Components = append(s.Components, &aiven.ServiceComponents{Component: c})
+		}
+		return s
+	}
+
+	// the component appears only after two polls of a RUNNING service
+	polls := []*aiven.Service{
+		running("kafka"),
+		running("kafka"),
+		running("kafka", "kafka_rest"),
+	}
+
+	w := &ServiceChangeWaiter{
+		Operation:        "create",
+		WaitForComponent: "kafka_rest",
+	}
+
+	var count int
+	conf := &resource.StateChangeConf{
+		Pending: []string{aivenPendingState, aivenRebalancingState, aivenServicesStartingState},
+		Target:  []string{aivenTargetState},
+		Refresh: func() (interface{}, string, error) {
+			service := polls[count]
+			if count < len(polls)-1 {
+				count++
+			}
+			return service, w.serviceState(service), nil
+		},
+		Timeout:      time.Minute,
+		PollInterval: time.Millisecond,
+	}
+
+	got, err := conf.WaitForState()
+	if err != nil {
+		t.Fatalf("WaitForState() error = %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("WaitForState() returned after %d polls, want the third one", count+1)
+	}
+
+	if !componentReady(got.(*aiven.Service), "kafka_rest") {
+		t.Errorf("WaitForState() returned a service without the kafka_rest component")
+	}
+}
+
+func Test_waitErrorReason(t *testing.T) {
+	// a PostgreSQL service is RUNNING but never gets its first backup
+	service := &aiven.Service{Type: "pg", Name: "test-pg", State: "RUNNING"}
+
+	w := &ServiceChangeWaiter{Operation: "create"}
+	conf := &resource.StateChangeConf{
+		Pending: []string{aivenPendingState, aivenRebalancingState, aivenServicesStartingState},
+		Target:  []string{aivenTargetState},
+		Refresh: func() (interface{}, string, error) {
+			return service, w.serviceState(service), nil
+		},
+		Timeout:      50 * time.Millisecond,
+		PollInterval: time.Millisecond,
+	}
+
+	_, err := conf.WaitForState()
+	if err == nil {
+		t.Fatal("WaitForState() error = nil, want a timeout")
+	}
+
+	got := w.waitError(err).Error()
+	if !strings.Contains(got, "waiting for the first backup of the service") {
+		t.Errorf("waitError() = %s, want it to include the reason", got)
+	}
+}
+
+func Test_serviceStatePoweredOff(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []string
+		state   string
+		want    string
+	}{
+		{"powering off", []string{aivenPoweredOffState}, "RUNNING", aivenPendingState},
+		{"powered off", []string{aivenPoweredOffState}, "POWEROFF", aivenPoweredOffState},
+		{"settling powered off", []string{aivenTargetState, aivenPoweredOffState}, "POWEROFF", aivenPoweredOffState},
+		{"settling running", []string{aivenTargetState, aivenPoweredOffState}, "RUNNING", aivenTargetState},
+		{"running", nil, "RUNNING", aivenTargetState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &ServiceChangeWaiter{Operation: "update", targets: tt.targets}
+
+			// a powered off grafana is never reachable, it must not be waited for
+			service := &aiven.Service{Type: "grafana", State: tt.state}
+			if got := w.serviceState(service); got != tt.want {
+				t.Errorf("serviceState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_serviceChangeWaiterPowerOff(t *testing.T) {
+	// the service is powered off after two polls and stays so
+	polls := []string{"RUNNING", "RUNNING", "POWEROFF"}
+
+	w := &ServiceChangeWaiter{Operation: "update"}
+	conf := w.Conf(time.Minute, aivenPoweredOffState)
+
+	var count int
+	conf.Refresh = func() (interface{}, string, error) {
+		service := &aiven.Service{Type: "pg", State: polls[count]}
+		if count < len(polls)-1 {
+			count++
+		}
+		return service, w.serviceState(service), nil
+	}
+	conf.Delay = 0
+	conf.PollInterval = time.Millisecond
+
+	got, err := conf.WaitForState()
+	if err != nil {
+		t.Fatalf("WaitForState() error = %v", err)
+	}
+
+	if state := got.(*aiven.Service).State; state != "POWEROFF" {
+		t.Errorf("WaitForState() returned a service in state %s, want POWEROFF", state)
+	}
+}
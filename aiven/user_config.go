@@ -88,9 +88,17 @@ func generateTerraformUserConfigSchema(key string, definition map[string]interfa
 			diffFunction = ipFilterArrayDiffSuppressFunc
 			valueDiffFunction = ipFilterValueDiffSuppressFunc
 		}
+		if userConfigUnorderedLists[key] {
+			diffFunction = anyDiffSuppressFunc(diffFunction, unorderedListDiffSuppressFunc(key))
+			valueDiffFunction = anyDiffSuppressFunc(valueDiffFunction, unorderedListDiffSuppressFunc(key))
+		}
 		var elem interface{}
 		if itemType == schema.TypeList {
-			elem = &schema.Resource{Schema: GenerateTerraformUserConfigSchema(itemDefinition)}
+			itemSchema := GenerateTerraformUserConfigSchema(itemDefinition)
+			if userConfigUnorderedLists[key] {
+				wrapUnorderedListDiffSuppressFunc(itemSchema, key)
+			}
+			elem = &schema.Resource{Schema: itemSchema}
 		} else {
 			elem = &schema.Schema{
 				DiffSuppressFunc: valueDiffFunction,
@@ -111,6 +119,91 @@ func generateTerraformUserConfigSchema(key string, definition map[string]interfa
 	}
 }
 
+// userConfigUnorderedLists lists the user config arrays the Aiven API treats as sets, the
+// order of their items is not significant and reordering them must not show up as a change
+var userConfigUnorderedLists = map[string]bool{
+	"allowed_domains":           true,
+	"allowed_groups":            true,
+	"allowed_organizations":     true,
+	"ignore_startup_parameters": true,
+	"ip_filter":                 true,
+	"namespaces":                true,
+	"reindex_remote_whitelist":  true,
+	"team_ids":                  true,
+}
+
+// unorderedListDiffSuppressFunc suppresses the diff of the list named name, or of any of
+// its items, when the old and new lists hold the same items in a different order
+func unorderedListDiffSuppressFunc(name string) schema.SchemaDiffSuppressFunc {
+	return func(k, _, _ string, d *schema.ResourceData) bool {
+		i := strings.LastIndex(k, "."+name+".")
+		if i == -1 {
+			return false
+		}
+
+		o, n := d.GetChange(k[:i+len(name)+1])
+		oldList, ok := o.([]interface{})
+		if !ok {
+			return false
+		}
+		newList, ok := n.([]interface{})
+		if !ok {
+			return false
+		}
+
+		return sameListItems(oldList, newList)
+	}
+}
+
+// sameListItems tells if both lists hold the same items regardless of their order
+func sameListItems(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int)
+	for _, v := range a {
+		counts[fmt.Sprint(v)]++
+	}
+	for _, v := range b {
+		key := fmt.Sprint(v)
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+
+	return true
+}
+
+// wrapUnorderedListDiffSuppressFunc makes every field of the items of an unordered list of
+// objects, nested fields included, ignore the reordering of the list
+func wrapUnorderedListDiffSuppressFunc(s map[string]*schema.Schema, name string) {
+	for _, v := range s {
+		v.DiffSuppressFunc = anyDiffSuppressFunc(v.DiffSuppressFunc, unorderedListDiffSuppressFunc(name))
+
+		switch elem := v.Elem.(type) {
+		case *schema.Resource:
+			wrapUnorderedListDiffSuppressFunc(elem.Schema, name)
+		case *schema.Schema:
+			elem.DiffSuppressFunc = anyDiffSuppressFunc(elem.DiffSuppressFunc, unorderedListDiffSuppressFunc(name))
+		}
+	}
+}
+
+// anyDiffSuppressFunc suppresses the diff when any of the given functions does
+func anyDiffSuppressFunc(funcs ...schema.SchemaDiffSuppressFunc) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		for _, f := range funcs {
+			if f != nil && f(k, old, new, d) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
 func getAivenSchemaType(value interface{}) string {
 	switch res := value.(type) {
 	case string:
@@ -390,6 +483,15 @@ func convertTerraformUserConfigValueToAPICompatibleFormatArray(value interface{}
 		omit = false
 	}
 
+	// an explicitly empty ip_filter opens the service to all, send the default value instead
+	// of omitting it so that a previously configured filter gets removed
+	if key == "ip_filter" {
+		if d, ok := definition["default"].([]interface{}); ok {
+			empty = d
+			omit = false
+		}
+	}
+
 	// when value is nil
 	if value == nil {
 		return empty, omit, nil
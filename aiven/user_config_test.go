@@ -1,11 +1,13 @@
 package aiven
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
 	"github.com/aiven/terraform-provider-aiven/aiven/templates"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -241,3 +243,97 @@ func Test_convertTerraformUserConfigToAPICompatibleFormat(t *testing.T) {
 		})
 	}
 }
+
+func Test_convertTerraformUserConfigToAPICompatibleFormatEmptyIPFilter(t *testing.T) {
+	entrySchema := templates.GetUserConfigSchema("service")["pg"].(map[string]interface{})
+	entrySchemaProps := entrySchema["properties"].(map[string]interface{})
+
+	tests := []struct {
+		name        string
+		newResource bool
+	}{
+		{"create", true},
+		{"update", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertTerraformUserConfigToAPICompatibleFormat("pg", tt.newResource, map[string]interface{}{
+				"ip_filter": []interface{}{},
+			}, entrySchemaProps)
+			assert.Equal(t, map[string]interface{}{"ip_filter": []interface{}{"0.0.0.0/0"}}, got)
+		})
+	}
+}
+
+func Test_unorderedListDiffSuppressFunc(t *testing.T) {
+	namespace := func(name, resolution string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":       name,
+			"type":       "aggregated",
+			"resolution": resolution,
+			"options": []interface{}{map[string]interface{}{
+				"retention_options": []interface{}{map[string]interface{}{
+					"retention_period_duration": "48h",
+				}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		old      map[string]interface{}
+		new      map[string]interface{}
+		wantDiff bool
+	}{
+		{
+			"ip_filter reordered",
+			map[string]interface{}{"ip_filter": []interface{}{"10.0.0.0/8", "192.168.0.0/16"}},
+			map[string]interface{}{"ip_filter": []interface{}{"192.168.0.0/16", "10.0.0.0/8"}},
+			false,
+		},
+		{
+			"ip_filter changed",
+			map[string]interface{}{"ip_filter": []interface{}{"10.0.0.0/8", "192.168.0.0/16"}},
+			map[string]interface{}{"ip_filter": []interface{}{"192.168.0.0/16", "172.16.0.0/12"}},
+			true,
+		},
+		{
+			"namespaces reordered",
+			map[string]interface{}{"namespaces": []interface{}{namespace("hourly", "1h"), namespace("daily", "24h")}},
+			map[string]interface{}{"namespaces": []interface{}{namespace("daily", "24h"), namespace("hourly", "1h")}},
+			false,
+		},
+		{
+			"namespaces changed",
+			map[string]interface{}{"namespaces": []interface{}{namespace("hourly", "1h"), namespace("daily", "24h")}},
+			map[string]interface{}{"namespaces": []interface{}{namespace("daily", "12h"), namespace("hourly", "1h")}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"m3db_user_config": generateServiceUserConfiguration("m3db"),
+				},
+			}
+
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"m3db_user_config": []interface{}{tt.old},
+			})
+			d.SetId("test-project/test-service")
+			state := d.State()
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"m3db_user_config": []interface{}{tt.new},
+			})
+
+			diff, err := r.Diff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if got := diff != nil && len(diff.Attributes) > 0; got != tt.wantDiff {
+				t.Errorf("Diff() = %v, want diff %v", diff, tt.wantDiff)
+			}
+		})
+	}
+}